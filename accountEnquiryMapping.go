@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// account enquiry is a pacs.008 that BI-FAST answers with the creditor
+	// details instead of settling it
+	accountEnquiryTxType          = "510"
+	accountEnquiryCategoryPurpose = accountEnquiryTxType + "01"
+	accountEnquiryLocalInstrument = "01"
+)
+
+// mapAccountEnquiry turns the flat PACS008AccEnq of the channel into the
+// BI-FAST account enquiry pacs.008
+func mapAccountEnquiry(req PACS008AccEnq) (BusMsg, error) {
+	if err := requireFields(map[string]string{
+		"creationDateTime":          req.Creationdatetime,
+		"InterBankSettlementAmount": req.Interbanksettlementamount,
+		"CurrencyCode":              req.Currencycode,
+		"DebtorBankID":              req.Debtorbankid,
+		"CreditorBankID":            req.Creditorbankid,
+		"CustomerAccountNumber":     req.Customeraccountnumber,
+	}); err != nil {
+		return BusMsg{}, err
+	}
+
+	var creDtTm ISODateTime
+	if err := creDtTm.UnmarshalText([]byte(req.Creationdatetime)); err != nil {
+		return BusMsg{}, fmt.Errorf("creationDateTime: %v", err)
+	}
+
+	amount, err := parseAmount(req.Interbanksettlementamount, req.Currencycode)
+	if err != nil {
+		return BusMsg{}, fmt.Errorf("InterBankSettlementAmount: %v", err)
+	}
+
+	// the flat request is one transaction
+	nbOfTxs, err := numberOfTransactions("NumberTransaction", req.Numbertransaction, 1)
+	if err != nil {
+		return BusMsg{}, err
+	}
+
+	tx := CreditTransferTransaction39{
+		PmtId: PaymentIdentification7{
+			EndToEndId: Max35Text(req.Endtoendid),
+			TxId:       Max35Text(req.Transactionid),
+		},
+		PmtTpInf: &PaymentTypeInformation28{
+			LclInstrm: &LocalInstrument2Choice{Prtry: accountEnquiryLocalInstrument},
+			CtgyPurp:  &CategoryPurpose1Choice{Prtry: accountEnquiryCategoryPurpose},
+		},
+		IntrBkSttlmAmt: ActiveCurrencyAndAmount{
+			Value: amount,
+			Ccy:   ActiveCurrencyCode(req.Currencycode),
+		},
+		ChrgBr:   chargeBearer(req.Chargebearer),
+		DbtrAgt:  financialInstitution(req.Debtorbankid),
+		CdtrAgt:  financialInstitution(req.Creditorbankid),
+		CdtrAcct: cashAccount(req.Customeraccountnumber, ""),
+	}
+
+	msg := BusMsg{
+		AppHdr: appHdr(req.Debtorbankid, req.Messageid, pacs008MsgDefIdr, creDtTm),
+	}
+	msg.Document.FIToFICstmrCdtTrf = &FIToFICustomerCreditTransferV08{
+		GrpHdr: GroupHeader93{
+			MsgId:    Max35Text(req.Messageid),
+			CreDtTm:  creDtTm,
+			NbOfTxs:  nbOfTxs,
+			SttlmInf: SettlementInstruction7{SttlmMtd: settlementMethod(req.Settlementmethod)},
+		},
+		CdtTrfTxInf: []CreditTransferTransaction39{tx},
+	}
+	if err := setControlSum(msg.Document.FIToFICstmrCdtTrf); err != nil {
+		return BusMsg{}, err
+	}
+	return msg, nil
+}
+
+// isAccountEnquiry tells an account enquiry pacs.008 apart from a credit
+// transfer, both share the message definition
+func isAccountEnquiry(msg BusMsg) bool {
+	if msg.Document.Kind() != kindPacs008 {
+		return false
+	}
+	txs := msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf
+	if len(txs) == 0 || txs[0].PmtTpInf == nil || txs[0].PmtTpInf.CtgyPurp == nil {
+		return false
+	}
+	return strings.HasPrefix(string(txs[0].PmtTpInf.CtgyPurp.Prtry), accountEnquiryTxType)
+}
+
+// mapAccountEnquiryResponse takes the creditor details out of the pacs.002
+// answering an account enquiry
+func mapAccountEnquiryResponse(msg BusMsg) (PACS008AccEnqResponse, error) {
+	if k := msg.Document.Kind(); k != kindPacs002 {
+		return PACS008AccEnqResponse{}, fmt.Errorf("account enquiry response is %s, expected %s", k, kindPacs002)
+	}
+	txs := msg.Document.FIToFIPmtStsRpt.TxInfAndSts
+	if len(txs) == 0 {
+		return PACS008AccEnqResponse{}, errors.New("account enquiry response without TxInfAndSts")
+	}
+	tx := txs[0]
+
+	res := PACS008AccEnqResponse{
+		Endtoendid: string(tx.OrgnlEndToEndId),
+		Status:     string(tx.TxSts),
+	}
+	if len(tx.StsRsnInf) > 0 && tx.StsRsnInf[0].Rsn != nil {
+		rsn := tx.StsRsnInf[0].Rsn
+		res.Reasoncode = string(rsn.Cd)
+		if res.Reasoncode == "" {
+			res.Reasoncode = string(rsn.Prtry)
+		}
+	}
+
+	if ref := tx.OrgnlTxRef; ref != nil {
+		if ref.Cdtr != nil && ref.Cdtr.Pty != nil {
+			res.Creditorname = string(ref.Cdtr.Pty.Nm)
+		}
+		if acct := ref.CdtrAcct; acct != nil {
+			if acct.Id != nil && acct.Id.Othr != nil {
+				res.Customeraccountnumber = string(acct.Id.Othr.Id)
+			}
+			if acct.Tp != nil {
+				res.Creditoraccounttype = string(acct.Tp.Cd)
+				if res.Creditoraccounttype == "" {
+					res.Creditoraccounttype = string(acct.Tp.Prtry)
+				}
+			}
+		}
+	}
+	if len(tx.SplmtryData) > 0 && tx.SplmtryData[0].Envlp.Cdtr != nil {
+		cdtr := tx.SplmtryData[0].Envlp.Cdtr
+		res.Creditortype = string(cdtr.Tp)
+		res.Creditorresidentstatus = string(cdtr.RsdntSts)
+		res.Creditortownname = string(cdtr.TwnNm)
+	}
+
+	if res.Endtoendid == "" || res.Status == "" {
+		return PACS008AccEnqResponse{}, errors.New("account enquiry response without OrgnlEndToEndId or TxSts")
+	}
+	if _, ok := transactionStatuses[res.Status]; !ok {
+		return PACS008AccEnqResponse{}, fmt.Errorf("account enquiry response with unknown TxSts %q", res.Status)
+	}
+	return res, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMapAccountEnquiryResponse(t *testing.T) {
+	testHeaders(t, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC))
+	var req PACS008AccEnq
+	if err := decodeFlatSpec(readSample(t, "PACS008AccEnq"), &req, true); err != nil {
+		t.Fatal(err)
+	}
+	enq, err := mapAccountEnquiry(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		status  string
+		change  func(*PaymentTransaction110, *BusMsg)
+		wantErr string
+		reason  string
+	}{
+		{"confirmed", "ACTC", nil, "", ""},
+		{"rejected", "RJCT", nil, "", "AC03"},
+		{"not a status report", "ACTC", func(_ *PaymentTransaction110, msg *BusMsg) { *msg = enq }, "account enquiry response is pacs.008.001.08", ""},
+		{"missing TxInfAndSts", "ACTC", func(_ *PaymentTransaction110, msg *BusMsg) {
+			msg.Document.FIToFIPmtStsRpt.TxInfAndSts = nil
+		}, "without TxInfAndSts", ""},
+		{"unknown status", "ACTC", func(tx *PaymentTransaction110, _ *BusMsg) { tx.TxSts = "OKAY" }, `unknown TxSts "OKAY"`, ""},
+		{"missing status", "ACTC", func(tx *PaymentTransaction110, _ *BusMsg) { tx.TxSts = "" }, "without OrgnlEndToEndId or TxSts", ""},
+		{"original not found", "ACTC", func(tx *PaymentTransaction110, _ *BusMsg) { tx.OrgnlEndToEndId = "" }, "without OrgnlEndToEndId or TxSts", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := ""
+			if tt.status == "RJCT" {
+				reason = "AC03"
+			}
+			msg, err := statusReport(enq, tt.status, reason)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.change != nil {
+				tt.change(&msg.Document.FIToFIPmtStsRpt.TxInfAndSts[0], &msg)
+			}
+
+			res, err := mapAccountEnquiryResponse(msg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Status != tt.status || res.Reasoncode != tt.reason || res.Customeraccountnumber != "987654321" {
+				t.Errorf("got %+v, want %s %s for account 987654321", res, tt.status, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // zones do not depend on the host having tzdata
+)
+
+// biFastZone is the time zone of the BI-FAST business day, WIB unless
+// configured otherwise. The date in BizMsgIdr, MsgId and EndToEndId is taken
+// in it, and ISO 20022 times given without zone are local to it.
+var biFastZone = mustLoadZone(defaultTimeZone)
+
+const defaultTimeZone = "Asia/Jakarta"
+
+func mustLoadZone(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+const (
+	partitionSize     = 10000000          // the first of the 8 sequence digits in the BI-FAST identifiers is the partition
+	maxDailySequence  = partitionSize - 1 // per partition and day
+	maxPartitionCount = 10
+)
+
+var errSequenceExhausted = errors.New("daily sequence exhausted")
+
+// dailySequence numbers the messages of a BI-FAST business day from 1. The
+// last number is written to path before it is handed out, so a restart on
+// the same day carries on instead of reusing identifiers. Gateway instances
+// sending with the same BIC keep apart by partition, the first digit of the
+// numbers they hand out.
+type dailySequence struct {
+	mu        sync.Mutex
+	path      string
+	partition uint64
+	day       string
+	last      uint64
+}
+
+// openDailySequence reads the sequence file written as "YYYYMMDD last",
+// a missing file starts the sequence. Every instance needs its own file and
+// partition.
+func openDailySequence(path string, partition int) (*dailySequence, error) {
+	if partition < 0 || partition >= maxPartitionCount {
+		return nil, fmt.Errorf("sequence partition %d is not 0 to %d", partition, maxPartitionCount-1)
+	}
+	s := &dailySequence{path: path, partition: uint64(partition)}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 || len(fields[0]) != 8 {
+		return nil, fmt.Errorf("sequence file %s: want YYYYMMDD and a number", path)
+	}
+	if s.last, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+		return nil, fmt.Errorf("sequence file %s: %v", path, err)
+	}
+	if s.last > maxDailySequence {
+		return nil, fmt.Errorf("sequence file %s: %d is beyond the %d of a partition", path, s.last, maxDailySequence)
+	}
+	s.day = fields[0]
+	return s, nil
+}
+
+// next returns the business day of now and its next sequence number, within
+// the partition
+func (s *dailySequence) next(now time.Time) (string, uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	day := now.In(biFastZone).Format("20060102")
+	last := s.last
+	if day != s.day {
+		last = 0
+	}
+	if last >= maxDailySequence {
+		return "", 0, errSequenceExhausted
+	}
+	last++
+
+	if err := s.save(day, last); err != nil {
+		return "", 0, err
+	}
+	s.day, s.last = day, last
+	return day, s.partition*partitionSize + last, nil
+}
+
+// save replaces the sequence file, a crash leaves either the old or the new one
+func (s *dailySequence) save(day string, last uint64) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("save sequence: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = fmt.Fprintf(tmp, "%s %d\n", day, last)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		return fmt.Errorf("save sequence: %v", err)
+	}
+	return nil
+}
+
+// appHdrBuilder completes the AppHdr and the identifiers of a BusMsg. The
+// identifiers follow BI-FAST, for BIC INDOIDJA and transaction type 010:
+//
+//	BizMsgIdr, MsgId  20210301INDOIDJA01012345678
+//	EndToEndId        20210301INDOIDJA010ORB12345678
+//
+// date, BIC8, transaction type, for EndToEndId the originator O and the
+// channel type, then the daily sequence.
+type appHdrBuilder struct {
+	seq         *dailySequence
+	channelType string
+	now         func() time.Time
+}
+
+func newAppHdrBuilder(seq *dailySequence, channelType string) *appHdrBuilder {
+	return &appHdrBuilder{seq: seq, channelType: channelType, now: time.Now}
+}
+
+func (b *appHdrBuilder) messageId(bic string, txType string) (string, error) {
+	day, n, err := b.seq.next(b.now())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%s%s%08d", day, bic8(bic), txType, n), nil
+}
+
+func (b *appHdrBuilder) endToEndId(bic string, txType string) (string, error) {
+	day, n, err := b.seq.next(b.now())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s%s%sO%s%08d", day, bic8(bic), txType, b.channelType, n), nil
+}
+
+func bic8(bic string) string {
+	if len(bic) > 8 {
+		return bic[:8]
+	}
+	return bic
+}
+
+// complete fills what the channel left out of msg: BizMsgIdr and MsgId,
+// EndToEndId of every credit transfer transaction and CreDt. It then
+// addresses the AppHdr from the BIC in Fr to BI-FAST and takes MsgDefIdr
+// from the Document.
+func (b *appHdrBuilder) complete(msg *BusMsg, txType string) error {
+	if err := msg.Document.checkChoice(); err != nil {
+		return err
+	}
+	hdr := &msg.AppHdr
+	if hdr.Fr.FIId == nil || hdr.Fr.FIId.FinInstnId.BICFI == "" {
+		return errors.New("AppHdr without Fr BICFI")
+	}
+	bic := string(hdr.Fr.FIId.FinInstnId.BICFI)
+
+	if hdr.BizMsgIdr == "" {
+		id, err := b.messageId(bic, txType)
+		if err != nil {
+			return err
+		}
+		hdr.BizMsgIdr = Max35Text(id)
+	}
+	if time.Time(hdr.CreDt).IsZero() {
+		hdr.CreDt = ISONormalisedDateTime(b.now().UTC())
+	}
+
+	var msgId *Max35Text
+	var creDtTm *ISODateTime
+	var endToEndIds []*Max35Text
+	switch d := msg.Document; {
+	case d.FIToFICstmrCdtTrf != nil:
+		msgId, creDtTm = &d.FIToFICstmrCdtTrf.GrpHdr.MsgId, &d.FIToFICstmrCdtTrf.GrpHdr.CreDtTm
+		for i := range d.FIToFICstmrCdtTrf.CdtTrfTxInf {
+			endToEndIds = append(endToEndIds, &d.FIToFICstmrCdtTrf.CdtTrfTxInf[i].PmtId.EndToEndId)
+		}
+	case d.FICdtTrf != nil:
+		msgId, creDtTm = &d.FICdtTrf.GrpHdr.MsgId, &d.FICdtTrf.GrpHdr.CreDtTm
+		for i := range d.FICdtTrf.CdtTrfTxInf {
+			endToEndIds = append(endToEndIds, &d.FICdtTrf.CdtTrfTxInf[i].PmtId.EndToEndId)
+		}
+	case d.FIToFIPmtStsRpt != nil:
+		msgId, creDtTm = &d.FIToFIPmtStsRpt.GrpHdr.MsgId, &d.FIToFIPmtStsRpt.GrpHdr.CreDtTm
+	case d.FIToFIPmtStsReq != nil:
+		msgId, creDtTm = &d.FIToFIPmtStsReq.GrpHdr.MsgId, &d.FIToFIPmtStsReq.GrpHdr.CreDtTm
+	}
+
+	if *msgId == "" {
+		*msgId = hdr.BizMsgIdr
+	}
+	if time.Time(*creDtTm).IsZero() {
+		*creDtTm = ISODateTime(time.Time(hdr.CreDt).In(biFastZone))
+	}
+	for _, endToEndId := range endToEndIds {
+		if *endToEndId != "" {
+			continue
+		}
+		id, err := b.endToEndId(bic, txType)
+		if err != nil {
+			return err
+		}
+		*endToEndId = Max35Text(id)
+	}
+
+	hdr.To = biFastParty(biFastHubBIC)
+	hdr.MsgDefIdr = Max35Text(msg.Document.Kind())
+	hdr.BizSvc = biFastBizSvc
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+var (
+	messageIdFormat  = regexp.MustCompile(`^(\d{8})INDOIDJA010(\d{8})$`)
+	endToEndIdFormat = regexp.MustCompile(`^(\d{8})INDOIDJA010ORB(\d{8})$`)
+)
+
+// the identifiers take the WIB date, restart at 1 with the next business day
+// and never repeat
+func TestIdentifiersAcrossDayRollover(t *testing.T) {
+	b := testHeaders(t, time.Time{})
+	seen := make(map[string]bool)
+	tests := []struct {
+		now      time.Time
+		day, seq string
+	}{
+		{time.Date(2021, 3, 1, 16, 59, 58, 0, time.UTC), "20210301", "00000001"},
+		{time.Date(2021, 3, 1, 16, 59, 59, 0, time.UTC), "20210301", "00000003"}, // 23:59:59 WIB
+		{time.Date(2021, 3, 1, 17, 0, 0, 0, time.UTC), "20210302", "00000001"},   // 00:00 WIB
+		{time.Date(2021, 3, 1, 23, 0, 0, 0, time.UTC), "20210302", "00000003"},
+		{time.Date(2021, 3, 2, 17, 0, 0, 0, time.UTC), "20210303", "00000001"},
+	}
+
+	for _, tt := range tests {
+		now := tt.now
+		b.now = func() time.Time { return now }
+
+		msgId, err := b.messageId("INDOIDJAXXX", creditTransferTxType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		endToEndId, err := b.endToEndId("INDOIDJAXXX", creditTransferTxType)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := messageIdFormat.FindStringSubmatch(msgId)
+		if m == nil || m[1] != tt.day || m[2] != tt.seq {
+			t.Errorf("%s: messageId = %s, want day %s and sequence %s", tt.now, msgId, tt.day, tt.seq)
+		}
+		if m := endToEndIdFormat.FindStringSubmatch(endToEndId); m == nil || m[1] != tt.day {
+			t.Errorf("%s: endToEndId = %s, want day %s", tt.now, endToEndId, tt.day)
+		}
+		if len(endToEndId) > 35 {
+			t.Errorf("endToEndId %s is longer than Max35Text", endToEndId)
+		}
+		for _, id := range []string{msgId, endToEndId} {
+			if seen[id[:8]+id[len(id)-8:]] {
+				t.Errorf("%s: %s repeats a day and sequence", tt.now, id)
+			}
+			seen[id[:8]+id[len(id)-8:]] = true
+		}
+	}
+}
+
+// a restart on the same day carries on, on the next day starts over
+func TestDailySequenceReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "netChannel.seq")
+	day := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	seq, err := openDailySequence(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, _, err := seq.next(day); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seq, err = openDailySequence(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, n, err := seq.next(day); err != nil || d != "20210301" || n != 4 {
+		t.Errorf("next after reopen = %s, %d, %v, want 20210301, 4", d, n, err)
+	}
+	if d, n, err := seq.next(day.Add(24 * time.Hour)); err != nil || d != "20210302" || n != 1 {
+		t.Errorf("next on the next day = %s, %d, %v, want 20210302, 1", d, n, err)
+	}
+}
+
+// instances sending with the same BIC hand out disjoint numbers
+func TestDailySequencePartitions(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	seen := make(map[uint64]int)
+
+	for _, partition := range []int{0, 1, 9} {
+		seq, err := openDailySequence(filepath.Join(dir, fmt.Sprintf("netChannel%d.seq", partition)), partition)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seq.day, seq.last = "20210301", maxDailySequence-2 // the end of the partition
+		for i := 0; i < 2; i++ {
+			_, n, err := seq.next(now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p, ok := seen[n]; ok {
+				t.Errorf("partitions %d and %d both hand out %d", p, partition, n)
+			}
+			seen[n] = partition
+			if n/partitionSize != uint64(partition) || n > 99999999 {
+				t.Errorf("partition %d hands out %d", partition, n)
+			}
+		}
+		if _, _, err := seq.next(now); err != errSequenceExhausted {
+			t.Errorf("partition %d after %d = %v, want %v", partition, maxDailySequence, err, errSequenceExhausted)
+		}
+	}
+
+	for _, partition := range []int{-1, 10} {
+		if _, err := openDailySequence(filepath.Join(dir, "netChannel.seq"), partition); err == nil {
+			t.Errorf("partition %d opened without error", partition)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// documentKind is the message a Document holds, named by its message
+// definition as in AppHdr.MsgDefIdr
+type documentKind string
+
+const (
+	kindPacs008 documentKind = pacs008MsgDefIdr
+	kindPacs002 documentKind = pacs002MsgDefIdr
+	kindPacs009 documentKind = pacs009MsgDefIdr
+	kindPacs028 documentKind = pacs028MsgDefIdr
+)
+
+var documentKinds = []documentKind{kindPacs008, kindPacs002, kindPacs009, kindPacs028}
+
+// element is the name of the Document member holding a message of kind k
+func (k documentKind) element() string {
+	switch k {
+	case kindPacs008:
+		return "FIToFICstmrCdtTrf"
+	case kindPacs002:
+		return "FIToFIPmtStsRpt"
+	case kindPacs009:
+		return "FICdtTrf"
+	case kindPacs028:
+		return "FIToFIPmtStsReq"
+	}
+	return ""
+}
+
+// namespace of a Document holding a message of kind k
+func (k documentKind) namespace() string {
+	return isoNamespacePrefix + string(k)
+}
+
+func documentKindOf(element string) documentKind {
+	for _, k := range documentKinds {
+		if k.element() == element {
+			return k
+		}
+	}
+	return ""
+}
+
+// Kind is the message d holds, empty unless exactly one member is set
+func (d Document) Kind() documentKind {
+	kinds := d.kinds()
+	if len(kinds) != 1 {
+		return ""
+	}
+	return kinds[0]
+}
+
+func (d Document) kinds() []documentKind {
+	var kinds []documentKind
+	for _, k := range documentKinds {
+		if d.message(k) != nil {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+// checkChoice fails unless exactly one member of d is set
+func (d Document) checkChoice() error {
+	kinds := d.kinds()
+	switch len(kinds) {
+	case 0:
+		return errors.New("Document without message")
+	case 1:
+		return nil
+	}
+
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = k.element()
+	}
+	return fmt.Errorf("Document with %s, expected exactly one message", strings.Join(names, " and "))
+}
+
+// message returns the member of d holding kind k, nil when it is not set
+func (d Document) message(k documentKind) interface{} {
+	switch k {
+	case kindPacs008:
+		if d.FIToFICstmrCdtTrf != nil {
+			return d.FIToFICstmrCdtTrf
+		}
+	case kindPacs002:
+		if d.FIToFIPmtStsRpt != nil {
+			return d.FIToFIPmtStsRpt
+		}
+	case kindPacs009:
+		if d.FICdtTrf != nil {
+			return d.FICdtTrf
+		}
+	case kindPacs028:
+		if d.FIToFIPmtStsReq != nil {
+			return d.FIToFIPmtStsReq
+		}
+	}
+	return nil
+}
+
+// newMessage sets the member of d holding kind k to an empty message and
+// returns it for decoding
+func (d *Document) newMessage(k documentKind) interface{} {
+	switch k {
+	case kindPacs008:
+		d.FIToFICstmrCdtTrf = &FIToFICustomerCreditTransferV08{}
+		return d.FIToFICstmrCdtTrf
+	case kindPacs002:
+		d.FIToFIPmtStsRpt = &FIToFIPaymentStatusReportV10{}
+		return d.FIToFIPmtStsRpt
+	case kindPacs009:
+		d.FICdtTrf = &FinancialInstitutionCreditTransferV09{}
+		return d.FICdtTrf
+	case kindPacs028:
+		d.FIToFIPmtStsReq = &FIToFIPaymentStatusRequestV04{}
+		return d.FIToFIPmtStsReq
+	}
+	return nil
+}
+
+// documentMembers is Document without its json methods
+type documentMembers Document
+
+func (d Document) MarshalJSON() ([]byte, error) {
+	if err := d.checkChoice(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(documentMembers(d))
+}
+
+// UnmarshalJSON rejects a Document with another number of messages than one,
+// a null member counts as missing
+func (d *Document) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	*d = Document{}
+	for name, raw := range members {
+		k := documentKindOf(name)
+		if k == "" {
+			return fmt.Errorf("unknown Document member %s", name)
+		}
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			continue
+		}
+		if err := json.Unmarshal(raw, d.newMessage(k)); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return d.checkChoice()
+}
+
+// checkMsgDefIdr fails when AppHdr announces another message than the
+// Document holds
+func (msg BusMsg) checkMsgDefIdr() error {
+	if err := msg.Document.checkChoice(); err != nil {
+		return err
+	}
+	if k := msg.Document.Kind(); string(msg.AppHdr.MsgDefIdr) != string(k) {
+		return fmt.Errorf("AppHdr.MsgDefIdr %q does not match the %s Document", msg.AppHdr.MsgDefIdr, k)
+	}
+	return nil
+}
+
+// parseBusMsgJSON reads a BusMsg as produced by the *ISO20022 Adapter*
+func parseBusMsgJSON(data []byte) (BusMsg, error) {
+	var msg BusMsg
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return BusMsg{}, err
+	}
+	if err := msg.checkMsgDefIdr(); err != nil {
+		return BusMsg{}, err
+	}
+	return msg, nil
+}
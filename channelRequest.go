@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// requestKind names the flat spec of jsonSpec.go a channel request is
+// written in. It travels to the *ISO20022 Adapter* in the msgTypeHeader.
+type requestKind string
+
+const (
+	kindCreditTransfer       requestKind = "PACS008CreditTransfer"
+	kindCreditTransferProxy  requestKind = "PACS008CTwProxy"
+	kindAccountEnquiry       requestKind = "PACS008AccEnq"
+	kindFICreditTransfer     requestKind = "PACS009FICreditTransfer"
+	kindCreditTransferReturn requestKind = "PACS008CTReturn"
+)
+
+const msgTypeHeader = "msgType"
+
+// txType is the BI-FAST transaction type of the requests of kind k
+func (k requestKind) txType() string {
+	switch k {
+	case kindAccountEnquiry:
+		return accountEnquiryTxType
+	case kindFICreditTransfer:
+		return fiCreditTransferTxType
+	case kindCreditTransferReturn:
+		return creditTransferReturnTxType
+	}
+	return creditTransferTxType
+}
+
+// mapChannelRequest decodes payload as the flat spec of kind, see
+// decodeFlatSpec, and maps it into the ISO 20022 BusMsg
+func mapChannelRequest(kind requestKind, payload []byte) (BusMsg, error) {
+	switch kind {
+	case kindCreditTransfer:
+		var req PACS008CreditTransfer
+		if err := decodeFlatSpec(payload, &req, config.StrictRequests); err != nil {
+			return BusMsg{}, err
+		}
+		return mapCreditTransfer(req)
+
+	case kindCreditTransferProxy:
+		var req PACS008CTwProxy
+		if err := decodeFlatSpec(payload, &req, config.StrictRequests); err != nil {
+			return BusMsg{}, err
+		}
+		return mapCreditTransferWithProxy(req)
+
+	case kindAccountEnquiry:
+		var req PACS008AccEnq
+		if err := decodeFlatSpec(payload, &req, config.StrictRequests); err != nil {
+			return BusMsg{}, err
+		}
+		return mapAccountEnquiry(req)
+
+	case kindFICreditTransfer:
+		var req PACS009FICreditTransfer
+		if err := decodeFlatSpec(payload, &req, config.StrictRequests); err != nil {
+			return BusMsg{}, err
+		}
+		return mapFICreditTransfer(req)
+
+	case kindCreditTransferReturn:
+		var req PACS008CTReturn
+		if err := decodeFlatSpec(payload, &req, config.StrictRequests); err != nil {
+			return BusMsg{}, err
+		}
+		return mapCreditTransferReturn(req)
+	}
+	return BusMsg{}, fmt.Errorf("unknown request kind %q", kind)
+}
+
+// encodeChannelRequest turns a channel request into the BusMsg JSON produced
+// to the request topic, a message breaking the ISO 20022 rules never leaves
+// the gateway. The BusMsg itself is kept for status inquiries. A request
+// that is not produced fails with a *requestError.
+func encodeChannelRequest(frame []byte) (requestKind, BusMsg, []byte, error) {
+	kind, payload, err := dispatchRequest(frame)
+	if err != nil {
+		return kind, BusMsg{}, nil, err
+	}
+
+	msg, err := buildChannelRequest(kind, payload)
+	if err != nil {
+		return kind, BusMsg{}, nil, &requestError{Kind: kind, Err: err}
+	}
+	value, err := json.Marshal(msg)
+	if err != nil {
+		releaseReturn(kind, msg)
+	}
+	return kind, msg, value, err
+}
+
+// buildChannelRequest maps payload of kind into a finished BusMsg
+func buildChannelRequest(kind requestKind, payload []byte) (BusMsg, error) {
+	msg, err := mapChannelRequest(kind, payload)
+	if err != nil {
+		return BusMsg{}, err
+	}
+	if err := finishMessage(&msg, kind.txType()); err != nil {
+		return BusMsg{}, err
+	}
+	if kind == kindCreditTransferReturn {
+		if err := recordReturn(msg); err != nil {
+			return BusMsg{}, err
+		}
+	}
+	return msg, nil
+}
+
+// finishMessage completes msg, of BI-FAST transaction type txType, with
+// identifiers and AppHdr, validates and signs it. Every message the gateway
+// produces goes through it.
+func finishMessage(msg *BusMsg, txType string) error {
+	if err := headers.complete(msg, txType); err != nil {
+		return err
+	}
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	return signatures.sign(msg)
+}
+
+// channelResponse turns the adapter response to a request of kind into what
+// the channel gets back. A response failing signature verification is not
+// forwarded, the channel gets it as rejected instead.
+func channelResponse(kind requestKind, content []byte) ([]byte, error) {
+	switch kind {
+	case kindAccountEnquiry:
+		msg, err := parseBusMsgJSON(content)
+		if err != nil {
+			return nil, fmt.Errorf("account enquiry response: %v", err)
+		}
+		if err := signatures.verify(msg); err != nil {
+			log.Printf("Rejecting account enquiry response: %v\n", err)
+			endToEndId, reason := signatureRejection(msg, err)
+			return json.Marshal(PACS008AccEnqResponse{Endtoendid: endToEndId, Status: "RJCT", Reasoncode: reason})
+		}
+		res, err := mapAccountEnquiryResponse(msg)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(res)
+
+	case kindCreditTransfer, kindCreditTransferProxy, kindFICreditTransfer, kindCreditTransferReturn:
+		msg, err := parseBusMsgJSON(content)
+		if err != nil {
+			return nil, fmt.Errorf("status report: %v", err)
+		}
+		if err := signatures.verify(msg); err != nil {
+			log.Printf("Rejecting status report: %v\n", err)
+			endToEndId, reason := signatureRejection(msg, err)
+			return json.Marshal(PACS002StatusResponse{
+				Endtoendid: endToEndId,
+				Status:     "RJCT",
+				Reasoncode: reason,
+				Reasontext: statusReasons[reason],
+			})
+		}
+		res, err := mapStatusReport(msg)
+		if err != nil {
+			return nil, err
+		}
+		if kind == kindCreditTransferReturn && res.Status == "RJCT" {
+			// the rejected return no longer counts against its original
+			if err := transactions.rejectReturn(res.Endtoendid); err != nil {
+				log.Printf("Fail to release return %s: %v\n", res.Endtoendid, err)
+			}
+		}
+		return json.Marshal(res)
+	}
+	return nil, fmt.Errorf("unknown request kind %q", kind)
+}
+
+// signatureRejection is the EndToEndId the unverified msg claims to answer
+// and the reason code for err
+func signatureRejection(msg BusMsg, err error) (string, string) {
+	var endToEndId string
+	if rpt := msg.Document.FIToFIPmtStsRpt; rpt != nil && len(rpt.TxInfAndSts) > 0 {
+		endToEndId = string(rpt.TxInfAndSts[0].OrgnlEndToEndId)
+	}
+	return endToEndId, signatureReason(err)
+}
+
+// signatureReason is the reason code for err from signatureService.verify
+func signatureReason(err error) string {
+	if se, ok := err.(*signatureError); ok {
+		return se.Reason
+	}
+	return reasonSignatureInvalid
+}
@@ -0,0 +1,562 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config of the gateway. Values are taken from the defaults, then the file
+// given with -config (YAML or JSON), then BIFAST_* environment variables,
+// then command-line flags, each overriding the one before.
+type Config struct {
+	NodeId            string           `yaml:"nodeId" json:"nodeId"`
+	Listeners         []ListenerConfig `yaml:"listeners" json:"listeners"`
+	Bus               string           `yaml:"bus" json:"bus"` // kafka, the memory bus only serves tests
+	ResponseTimeout   duration         `yaml:"responseTimeout" json:"responseTimeout"`
+	MaxPending        int              `yaml:"maxPending" json:"maxPending"`
+	SequenceFile      string           `yaml:"sequenceFile" json:"sequenceFile"`           // daily sequence of generated BI-FAST identifiers
+	SequencePartition int              `yaml:"sequencePartition" json:"sequencePartition"` // 0-9, distinct for every instance sending with the same BIC
+	TransactionFile   string           `yaml:"transactionFile" json:"transactionFile"`     // received credit transfers and their returns
+	ChannelType       string           `yaml:"channelType" json:"channelType"`             // 2 characters in generated EndToEndIds
+	TimeZone          string           `yaml:"timeZone" json:"timeZone"`                   // of the business day and of times given without zone
+	StatusInquiry     []duration       `yaml:"statusInquiry" json:"statusInquiry"`         // waits of the pacs.028 sent after responseTimeout, see inquireStatus
+	StrictRequests    bool             `yaml:"strictRequests" json:"strictRequests"`       // reject channel requests with keys unknown to their flat spec
+	Kafka             KafkaConfig      `yaml:"kafka" json:"kafka"`
+	Signature         SignatureConfig  `yaml:"signature" json:"signature"`
+}
+
+type ListenerConfig struct {
+	Network string `yaml:"network" json:"network"`
+	Address string `yaml:"address" json:"address"`
+	Framing string `yaml:"framing" json:"framing"` // one of the names in `framers`
+}
+
+type KafkaConfig struct {
+	Brokers       string `yaml:"brokers" json:"brokers"`
+	GroupId       string `yaml:"groupId" json:"groupId"`
+	RequestTopic  string `yaml:"requestTopic" json:"requestTopic"`
+	ResponseTopic string `yaml:"responseTopic" json:"responseTopic"`
+
+	// credit transfers BI-FAST sends to us go to core banking on this topic,
+	// its decision comes back on ResponseTopic, see answerCreditTransfer
+	CoreBankingTopic string `yaml:"coreBankingTopic" json:"coreBankingTopic"`
+
+	// request kind, e.g. PACS008AccEnq, to the topic its requests are
+	// produced to instead of RequestTopic
+	Topics map[string]string `yaml:"topics" json:"topics"`
+
+	// librdkafka properties passed as is to kafka.ConfigMap, e.g.
+	// security.protocol, sasl.mechanisms, ssl.ca.location. Properties apply
+	// to producer and consumer, Producer/Consumer only to one of them.
+	Properties map[string]string `yaml:"properties" json:"properties"`
+	Producer   map[string]string `yaml:"producer" json:"producer"`
+	Consumer   map[string]string `yaml:"consumer" json:"consumer"`
+}
+
+// SignatureConfig turns on signing of outgoing messages (Keystore) and
+// verification of incoming ones (Trusted), see signatureService
+type SignatureConfig struct {
+	Keystore string `yaml:"keystore" json:"keystore"` // PEM, or PKCS#12 when named .p12/.pfx
+	Password string `yaml:"password" json:"password"` // of a PKCS#12 keystore
+
+	// BIC of a counterparty to the PEM file of the certificates it signs with
+	Trusted map[string]string `yaml:"trusted" json:"trusted"`
+}
+
+// duration reads "50s", "1m30s" etc. from YAML, JSON and the environment
+type duration time.Duration
+
+func (d *duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+func (d duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func defaultConfig() Config {
+	return Config{
+		Listeners:       []ListenerConfig{{Network: "tcp", Address: "0.0.0.0:3380", Framing: defaultFraming}},
+		Bus:             "kafka",
+		ResponseTimeout: duration(50 * time.Second),
+		MaxPending:      10000,
+		SequenceFile:    "netChannel.seq",
+		TransactionFile: "netChannel.tx",
+		ChannelType:     "RB",
+		TimeZone:        defaultTimeZone,
+		StatusInquiry:   []duration{duration(10 * time.Second), duration(20 * time.Second), duration(30 * time.Second)},
+		Kafka: KafkaConfig{
+			Brokers:       "localhost:9092",
+			GroupId:       "test",
+			RequestTopic:  "mpc.json.bifast.request",
+			ResponseTopic: "mpc.json.bifast.response",
+
+			CoreBankingTopic: "mpc.json.bifast.corebanking",
+		},
+	}
+}
+
+// loadConfig builds the Config from file, environment and args (without the
+// program name) and validates it
+func loadConfig(args []string, environ []string) (Config, error) {
+	fs := flag.NewFlagSet("netChannel", flag.ContinueOnError)
+	configFile := fs.String("config", "", "YAML or JSON configuration file")
+	nodeId := fs.String("node-id", "", "node id prefixed to correlation ids, unique per gateway instance")
+	var listen listFlag
+	fs.Var(&listen, "listen", "listener as address[,framing], may be repeated")
+	bus := fs.String("bus", "", "message bus: kafka")
+	responseTimeout := fs.String("response-timeout", "", "how long a request waits for its response, e.g. 50s")
+	maxPending := fs.Int("max-pending", 0, "max requests waiting for response at the same time")
+	sequenceFile := fs.String("sequence-file", "", "file keeping the daily sequence of generated identifiers")
+	sequencePartition := fs.Int("sequence-partition", 0, "0-9, first digit of the daily sequence, distinct for every instance with the same BIC")
+	transactionFile := fs.String("transaction-file", "", "file keeping received credit transfers and their returns")
+	channelType := fs.String("channel-type", "", "channel type in generated EndToEndIds, e.g. RB")
+	timeZone := fs.String("time-zone", "", "zone of the business day and of times given without zone, e.g. Asia/Jakarta")
+	var statusInquiry listFlag
+	fs.Var(&statusInquiry, "status-inquiry", "wait of a status inquiry after a credit transfer timed out, e.g. 10s, may be repeated")
+	strictRequests := fs.Bool("strict-requests", false, "reject channel requests with keys unknown to their flat spec")
+	brokers := fs.String("brokers", "", "Kafka bootstrap servers")
+	groupId := fs.String("group-id", "", "Kafka consumer group")
+	requestTopic := fs.String("request-topic", "", "Kafka topic requests are produced to")
+	responseTopic := fs.String("response-topic", "", "Kafka topic responses are consumed from")
+	coreBankingTopic := fs.String("core-banking-topic", "", "Kafka topic inbound credit transfers are produced to for core banking")
+	var topics listFlag
+	fs.Var(&topics, "kafka-topic", "topic of one request kind as kind=topic, may be repeated")
+	var props listFlag
+	fs.Var(&props, "kafka-property", "librdkafka property as key=value, may be repeated")
+	keystore := fs.String("keystore", "", "PEM or PKCS#12 keystore signing outgoing messages")
+	var trusted listFlag
+	fs.Var(&trusted, "trusted", "certificates trusted for a counterparty as BIC=file.pem, may be repeated")
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	cfg := defaultConfig()
+	if *configFile != "" {
+		if err := cfg.readFile(*configFile); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := cfg.applyEnv(environ); err != nil {
+		return Config{}, err
+	}
+
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "node-id":
+			cfg.NodeId = *nodeId
+		case "listen":
+			cfg.Listeners, err = parseListeners(listen)
+		case "bus":
+			cfg.Bus = *bus
+		case "response-timeout":
+			err = cfg.ResponseTimeout.UnmarshalText([]byte(*responseTimeout))
+		case "max-pending":
+			cfg.MaxPending = *maxPending
+		case "sequence-file":
+			cfg.SequenceFile = *sequenceFile
+		case "sequence-partition":
+			cfg.SequencePartition = *sequencePartition
+		case "transaction-file":
+			cfg.TransactionFile = *transactionFile
+		case "channel-type":
+			cfg.ChannelType = *channelType
+		case "time-zone":
+			cfg.TimeZone = *timeZone
+		case "status-inquiry":
+			cfg.StatusInquiry, err = parseDurations(statusInquiry)
+		case "strict-requests":
+			cfg.StrictRequests = *strictRequests
+		case "brokers":
+			cfg.Kafka.Brokers = *brokers
+		case "group-id":
+			cfg.Kafka.GroupId = *groupId
+		case "request-topic":
+			cfg.Kafka.RequestTopic = *requestTopic
+		case "response-topic":
+			cfg.Kafka.ResponseTopic = *responseTopic
+		case "core-banking-topic":
+			cfg.Kafka.CoreBankingTopic = *coreBankingTopic
+		case "kafka-topic":
+			for _, t := range topics {
+				kv := strings.SplitN(t, "=", 2)
+				if len(kv) != 2 {
+					err = fmt.Errorf("-kafka-topic %q: want kind=topic", t)
+					return
+				}
+				if err = cfg.Kafka.setTopic(kv[0], kv[1]); err != nil {
+					return
+				}
+			}
+		case "kafka-property":
+			for _, p := range props {
+				kv := strings.SplitN(p, "=", 2)
+				if len(kv) != 2 {
+					err = fmt.Errorf("-kafka-property %q: want key=value", p)
+					return
+				}
+				cfg.Kafka.setProperty(kv[0], kv[1])
+			}
+		case "keystore":
+			cfg.Signature.Keystore = *keystore
+		case "trusted":
+			for _, t := range trusted {
+				kv := strings.SplitN(t, "=", 2)
+				if len(kv) != 2 {
+					err = fmt.Errorf("-trusted %q: want BIC=file", t)
+					return
+				}
+				cfg.Signature.setTrusted(kv[0], kv[1])
+			}
+		}
+		if err != nil {
+			err = fmt.Errorf("-%s: %v", f.Name, err)
+		}
+	})
+	if err != nil {
+		return Config{}, err
+	}
+
+	// listeners from a file may leave out network and framing
+	for i := range cfg.Listeners {
+		if cfg.Listeners[i].Network == "" {
+			cfg.Listeners[i].Network = "tcp"
+		}
+		if cfg.Listeners[i].Framing == "" {
+			cfg.Listeners[i].Framing = defaultFraming
+		}
+	}
+
+	return cfg, cfg.validate()
+}
+
+func (cfg *Config) readFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config: %v", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(data, cfg)
+	case ".json":
+		dec := json.NewDecoder(strings.NewReader(string(data)))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(cfg)
+	default:
+		return fmt.Errorf("config %s: unknown format, use .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return fmt.Errorf("config %s: %v", path, err)
+	}
+	return nil
+}
+
+// envPrefix of the environment variables, e.g. BIFAST_KAFKA_BROKERS.
+// BIFAST_KAFKA_PROPERTY_SASL_MECHANISMS sets the librdkafka property
+// sasl.mechanisms.
+const envPrefix = "BIFAST_"
+
+func (cfg *Config) applyEnv(environ []string) error {
+	for _, kv := range environ {
+		i := strings.IndexByte(kv, '=')
+		if i < 0 || !strings.HasPrefix(kv, envPrefix) {
+			continue
+		}
+		key, value := kv[len(envPrefix):i], kv[i+1:]
+
+		var err error
+		switch key {
+		case "NODE_ID":
+			cfg.NodeId = value
+		case "LISTEN":
+			cfg.Listeners, err = parseListeners(strings.Fields(value))
+		case "BUS":
+			cfg.Bus = value
+		case "RESPONSE_TIMEOUT":
+			err = cfg.ResponseTimeout.UnmarshalText([]byte(value))
+		case "MAX_PENDING":
+			cfg.MaxPending, err = strconv.Atoi(value)
+		case "SEQUENCE_FILE":
+			cfg.SequenceFile = value
+		case "SEQUENCE_PARTITION":
+			cfg.SequencePartition, err = strconv.Atoi(value)
+		case "TRANSACTION_FILE":
+			cfg.TransactionFile = value
+		case "CHANNEL_TYPE":
+			cfg.ChannelType = value
+		case "TIME_ZONE":
+			cfg.TimeZone = value
+		case "STATUS_INQUIRY":
+			cfg.StatusInquiry, err = parseDurations(strings.Fields(value))
+		case "STRICT_REQUESTS":
+			cfg.StrictRequests, err = strconv.ParseBool(value)
+		case "KAFKA_BROKERS":
+			cfg.Kafka.Brokers = value
+		case "KAFKA_GROUP_ID":
+			cfg.Kafka.GroupId = value
+		case "KAFKA_REQUEST_TOPIC":
+			cfg.Kafka.RequestTopic = value
+		case "KAFKA_RESPONSE_TOPIC":
+			cfg.Kafka.ResponseTopic = value
+		case "KAFKA_CORE_BANKING_TOPIC":
+			cfg.Kafka.CoreBankingTopic = value
+		case "SIGNATURE_KEYSTORE":
+			cfg.Signature.Keystore = value
+		case "SIGNATURE_PASSWORD":
+			cfg.Signature.Password = value
+		default:
+			if strings.HasPrefix(key, "KAFKA_PROPERTY_") {
+				name := strings.ToLower(strings.TrimPrefix(key, "KAFKA_PROPERTY_"))
+				cfg.Kafka.setProperty(strings.Replace(name, "_", ".", -1), value)
+			}
+			if strings.HasPrefix(key, "KAFKA_TOPIC_") {
+				err = cfg.Kafka.setTopic(strings.TrimPrefix(key, "KAFKA_TOPIC_"), value)
+			}
+			if strings.HasPrefix(key, "SIGNATURE_TRUSTED_") {
+				cfg.Signature.setTrusted(strings.TrimPrefix(key, "SIGNATURE_TRUSTED_"), value)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("%s%s: %v", envPrefix, key, err)
+		}
+	}
+	return nil
+}
+
+func (k *KafkaConfig) setProperty(key string, value string) {
+	if k.Properties == nil {
+		k.Properties = make(map[string]string)
+	}
+	k.Properties[key] = value
+}
+
+// setTopic routes the requests of the kind named name, in any case, to topic
+func (k *KafkaConfig) setTopic(name string, topic string) error {
+	kind, ok := parseRequestKind(name)
+	if !ok {
+		return fmt.Errorf("unknown request kind %q", name)
+	}
+	if k.Topics == nil {
+		k.Topics = make(map[string]string)
+	}
+	k.Topics[string(kind)] = topic
+	return nil
+}
+
+var (
+	channelTypePattern = regexp.MustCompile(`^[A-Z0-9]{2}$`)
+	bicPattern         = regexp.MustCompile(`^[A-Z0-9]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+)
+
+func (s *SignatureConfig) setTrusted(bic string, path string) {
+	if s.Trusted == nil {
+		s.Trusted = make(map[string]string)
+	}
+	s.Trusted[bic] = path
+}
+
+// reservedKafkaProperties have their own setting in KafkaConfig
+var reservedKafkaProperties = []string{"bootstrap.servers", "group.id"}
+
+// validate reports every problem at once, so a broken deployment can be
+// fixed in one go
+func (cfg Config) validate() error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	// derived from the host name two containers named alike would share
+	// correlation ids, so every instance is given its own
+	if cfg.NodeId == "" {
+		add("nodeId is required, unique per gateway instance")
+	} else if !nodeIdPattern.MatchString(strings.ToUpper(cfg.NodeId)) {
+		add("nodeId %q must be 1 to 8 characters of A-Z, 0-9", cfg.NodeId)
+	}
+
+	if len(cfg.Listeners) == 0 {
+		add("listeners: at least one listener is required")
+	}
+	seen := make(map[string]bool)
+	for i, ln := range cfg.Listeners {
+		if ln.Network != "tcp" && ln.Network != "tcp4" && ln.Network != "tcp6" {
+			add("listeners[%d].network %q must be tcp, tcp4 or tcp6", i, ln.Network)
+		}
+		if _, _, err := net.SplitHostPort(ln.Address); err != nil {
+			add("listeners[%d].address %q: %v", i, ln.Address, err)
+		}
+		if seen[ln.Address] {
+			add("listeners[%d].address %q is used twice", i, ln.Address)
+		}
+		seen[ln.Address] = true
+		if _, err := newFramer(ln.Framing); err != nil {
+			add("listeners[%d].framing: %v", i, err)
+		}
+	}
+
+	if cfg.Bus != "kafka" {
+		// a memory bus has no adapter behind it, every request would time out
+		add("bus %q must be kafka", cfg.Bus)
+	}
+	if cfg.ResponseTimeout <= 0 {
+		add("responseTimeout must be positive")
+	}
+	if cfg.MaxPending <= 0 {
+		add("maxPending must be positive")
+	}
+	if cfg.SequenceFile == "" {
+		add("sequenceFile is required")
+	}
+	if cfg.SequencePartition < 0 || cfg.SequencePartition >= maxPartitionCount {
+		add("sequencePartition %d must be 0 to %d", cfg.SequencePartition, maxPartitionCount-1)
+	}
+	if cfg.TransactionFile == "" {
+		add("transactionFile is required")
+	}
+	if !channelTypePattern.MatchString(cfg.ChannelType) {
+		add("channelType %q must be 2 characters of A-Z, 0-9", cfg.ChannelType)
+	}
+	if _, err := time.LoadLocation(cfg.TimeZone); err != nil || cfg.TimeZone == "" {
+		add("timeZone %q is not a time zone", cfg.TimeZone)
+	}
+	for i, d := range cfg.StatusInquiry {
+		if d <= 0 {
+			add("statusInquiry[%d] must be positive", i)
+		}
+	}
+
+	if cfg.Bus == "kafka" && cfg.Kafka.Brokers == "" {
+		add("kafka.brokers is required")
+	}
+	if cfg.Kafka.GroupId == "" {
+		add("kafka.groupId is required")
+	}
+	if cfg.Kafka.RequestTopic == "" {
+		add("kafka.requestTopic is required")
+	}
+	if cfg.Kafka.ResponseTopic == "" {
+		add("kafka.responseTopic is required")
+	}
+	if cfg.Kafka.RequestTopic != "" && cfg.Kafka.RequestTopic == cfg.Kafka.ResponseTopic {
+		add("kafka.requestTopic and kafka.responseTopic must differ")
+	}
+	if cfg.Kafka.CoreBankingTopic == "" {
+		add("kafka.coreBankingTopic is required")
+	}
+	for _, topic := range append(cfg.Kafka.requestTopics(), cfg.Kafka.ResponseTopic) {
+		if topic != "" && topic == cfg.Kafka.CoreBankingTopic {
+			add("kafka.coreBankingTopic %q is taken by requests or responses", topic)
+		}
+	}
+	for name, topic := range cfg.Kafka.Topics {
+		if kind, ok := parseRequestKind(name); !ok || string(kind) != name {
+			add("kafka.topics: %q is not a request kind such as %s", name, kindAccountEnquiry)
+		}
+		if topic == "" {
+			add("kafka.topics.%s: no topic", name)
+		}
+		if topic != "" && topic == cfg.Kafka.ResponseTopic {
+			add("kafka.topics.%s and kafka.responseTopic must differ", name)
+		}
+	}
+	for _, name := range reservedKafkaProperties {
+		for section, props := range map[string]map[string]string{
+			"properties": cfg.Kafka.Properties,
+			"producer":   cfg.Kafka.Producer,
+			"consumer":   cfg.Kafka.Consumer,
+		} {
+			if _, ok := props[name]; ok {
+				add("kafka.%s: %s is set with its own setting", section, name)
+			}
+		}
+	}
+
+	if cfg.Signature.Password != "" && cfg.Signature.Keystore == "" {
+		add("signature.password is set without signature.keystore")
+	}
+	for bic, path := range cfg.Signature.Trusted {
+		if !bicPattern.MatchString(bic) {
+			add("signature.trusted: %q is not a BIC", bic)
+		}
+		if path == "" {
+			add("signature.trusted.%s: no certificate file", bic)
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration:\n  " + strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// parseListeners reads listeners written as address[,framing]
+func parseListeners(values []string) ([]ListenerConfig, error) {
+	var listeners []ListenerConfig
+	for _, v := range values {
+		ln := ListenerConfig{Network: "tcp", Framing: defaultFraming}
+		parts := strings.SplitN(v, ",", 2)
+		ln.Address = parts[0]
+		if len(parts) == 2 {
+			ln.Framing = parts[1]
+		}
+		listeners = append(listeners, ln)
+	}
+	if len(listeners) == 0 {
+		return nil, errors.New("no listener given")
+	}
+	return listeners, nil
+}
+
+// parseDurations reads durations such as 10s, one per value
+func parseDurations(values []string) ([]duration, error) {
+	durations := make([]duration, len(values))
+	for i, v := range values {
+		if err := durations[i].UnmarshalText([]byte(v)); err != nil {
+			return nil, err
+		}
+	}
+	return durations, nil
+}
+
+// listFlag collects a flag given more than once
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, " ")
+}
+
+func (l *listFlag) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// configMap turns the Kafka settings into the kafka.ConfigMap entries of a
+// producer or consumer, role specific properties win over common ones
+func (k KafkaConfig) configMap(role map[string]string) map[string]string {
+	m := make(map[string]string, len(k.Properties)+len(role))
+	for key, v := range k.Properties {
+		m[key] = v
+	}
+	for key, v := range role {
+		m[key] = v
+	}
+	return m
+}
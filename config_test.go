@@ -0,0 +1,135 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// the memory bus has no adapter behind it, a gateway on it answers nothing
+func TestConfigRejectsMemoryBus(t *testing.T) {
+	_, err := loadConfig([]string{"-bus", "memory"}, nil)
+	if err == nil || !strings.Contains(err.Error(), `bus "memory" must be kafka`) {
+		t.Errorf("loadConfig with -bus memory = %v, want it rejected", err)
+	}
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// every source overrides the one before: defaults, file, BIFAST_*
+// environment, flags
+func TestConfigPrecedence(t *testing.T) {
+	files := map[string]string{
+		"netChannel.yaml": "nodeId: GW01\nresponseTimeout: 20s\nmaxPending: 5\nchannelType: FL\nkafka:\n  brokers: file:9092\n",
+		"netChannel.json": `{"nodeId": "GW01", "responseTimeout": "20s", "maxPending": 5, "channelType": "FL", "kafka": {"brokers": "file:9092"}}`,
+	}
+	environ := []string{"BIFAST_MAX_PENDING=6", "BIFAST_CHANNEL_TYPE=EN", "MAX_PENDING=7", "BIFAST_KAFKA_PROPERTY_SASL_MECHANISMS=PLAIN"}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			args := []string{"-config", writeConfig(t, name, content), "-channel-type", "FG"}
+			cfg, err := loadConfig(args, environ)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tests := []struct {
+				name      string
+				got, want interface{}
+			}{
+				{"default", cfg.Kafka.GroupId, "test"},
+				{"file over default", cfg.ResponseTimeout, duration(20 * time.Second)},
+				{"file over default", cfg.Kafka.Brokers, "file:9092"},
+				{"environment over file", cfg.MaxPending, 6},
+				{"environment property", cfg.Kafka.Properties["sasl.mechanisms"], "PLAIN"},
+				{"flag over environment", cfg.ChannelType, "FG"},
+			}
+			for _, tt := range tests {
+				if tt.got != tt.want {
+					t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigRejectsUnknownKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{"yaml", "netChannel.yaml", "nodeId: GW01\nmaxPendng: 5\n", "maxPendng"},
+		{"yaml nested", "netChannel.yml", "kafka:\n  broker: localhost:9092\n", "broker"},
+		{"json", "netChannel.json", `{"nodeId": "GW01", "maxPendng": 5}`, "maxPendng"},
+		{"json nested", "netChannel.json", `{"kafka": {"broker": "localhost:9092"}}`, "broker"},
+		{"unknown format", "netChannel.toml", "nodeId = \"GW01\"\n", "unknown format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfig([]string{"-config", writeConfig(t, tt.file, tt.content)}, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// validate reports all problems in one error, one per line
+func TestConfigValidateListsEveryProblem(t *testing.T) {
+	args := []string{
+		"-node-id", "GW-01",
+		"-listen", "0.0.0.0:3380,len3",
+		"-response-timeout", "0s",
+		"-max-pending", "0",
+		"-sequence-partition", "12",
+		"-channel-type", "R",
+		"-response-topic", "mpc.json.bifast.request",
+	}
+	_, err := loadConfig(args, []string{"BIFAST_TIME_ZONE=Asia/Nowhere", "BIFAST_SIGNATURE_TRUSTED_BIFA=ca.pem"})
+	if err == nil {
+		t.Fatal("invalid configuration accepted")
+	}
+
+	want := []string{
+		`nodeId "GW-01"`,
+		`listeners[0].framing: unknown framing "len3"`,
+		"responseTimeout must be positive",
+		"maxPending must be positive",
+		"sequencePartition 12",
+		`channelType "R"`,
+		`timeZone "Asia/Nowhere"`,
+		"kafka.requestTopic and kafka.responseTopic must differ",
+		`signature.trusted: "BIFA" is not a BIC`,
+	}
+	for _, problem := range want {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("error does not report %q:\n%v", problem, err)
+		}
+	}
+	if lines := strings.Count(err.Error(), "\n"); lines != len(want) {
+		t.Errorf("error reports %d problems, want %d:\n%v", lines, len(want), err)
+	}
+}
+
+// a node id derived from the host name would be shared by hosts named alike
+func TestConfigRequiresNodeId(t *testing.T) {
+	_, err := loadConfig(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "nodeId is required") {
+		t.Errorf("loadConfig without node id = %v, want it rejected", err)
+	}
+	if _, err := loadConfig([]string{"-node-id", "GW01"}, nil); err != nil {
+		t.Errorf("loadConfig with -node-id GW01: %v", err)
+	}
+}
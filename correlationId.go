@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	startWidth = 9 // process start in ms, base36, good until year 5188
+	seqWidth   = 7 // ~78 billion ids per process before the width grows
+)
+
+var nodeIdPattern = regexp.MustCompile(`^[A-Z0-9]{1,8}$`)
+
+// correlationId pairs a request produced to Kafka with its response
+type correlationId struct {
+	id   string // carried in `resConsume.Head` and the Kafka header, max 24 chars
+	stan string // 6 digit system trace audit number derived from the sequence
+}
+
+// correlationIdGenerator builds ids as node id + process start + sequence.
+// The node id keeps gateway instances apart, the process start keeps
+// restarts of the same node apart and the sequence keeps requests within
+// the same millisecond apart. All parts are fixed width base36, so ids of
+// one node sort in the order they were generated.
+type correlationIdGenerator struct {
+	prefix string
+	seq    uint64
+}
+
+func newCorrelationIdGenerator(node string, start time.Time) (*correlationIdGenerator, error) {
+	node = strings.ToUpper(node)
+	if !nodeIdPattern.MatchString(node) {
+		return nil, fmt.Errorf("node id %q must be 1 to 8 characters of A-Z, 0-9", node)
+	}
+
+	ms := start.UnixNano() / int64(time.Millisecond)
+	return &correlationIdGenerator{
+		prefix: node + base36(uint64(ms), startWidth),
+	}, nil
+}
+
+func (g *correlationIdGenerator) next() correlationId {
+	seq := atomic.AddUint64(&g.seq, 1)
+	return correlationId{
+		id:   g.prefix + base36(seq, seqWidth),
+		stan: fmt.Sprintf("%06d", seq%1000000),
+	}
+}
+
+func base36(n uint64, width int) string {
+	s := strings.ToUpper(strconv.FormatUint(n, 36))
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	return s
+}
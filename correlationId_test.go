@@ -0,0 +1,97 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCorrelationIdsUnique(t *testing.T) {
+	start := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	generators := []*correlationIdGenerator{}
+	for _, g := range []struct {
+		node  string
+		start time.Time
+	}{
+		{"GATEWAY1", start},
+		{"GATEWAY2", start},
+		{"gateway1", start.Add(time.Millisecond)}, // restarted
+	} {
+		gen, err := newCorrelationIdGenerator(g.node, g.start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		generators = append(generators, gen)
+	}
+
+	const workers, perWorker = 8, 2000
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+	var wg sync.WaitGroup
+	for _, gen := range generators {
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(gen *correlationIdGenerator) {
+				defer wg.Done()
+				for i := 0; i < perWorker; i++ {
+					cid := gen.next()
+					mu.Lock()
+					if seen[cid.id] {
+						t.Errorf("id %s generated twice", cid.id)
+					}
+					seen[cid.id] = true
+					mu.Unlock()
+					if len(cid.id) > 24 {
+						t.Errorf("id %s has %d characters, at most 24 allowed", cid.id, len(cid.id))
+					}
+				}
+			}(gen)
+		}
+	}
+	wg.Wait()
+	if len(seen) != len(generators)*workers*perWorker {
+		t.Errorf("got %d ids, want %d", len(seen), len(generators)*workers*perWorker)
+	}
+}
+
+func TestCorrelationIdSequence(t *testing.T) {
+	g, err := newCorrelationIdGenerator("GW01", time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		seq  uint64
+		stan string
+	}{
+		{1, "000001"},
+		{42, "000042"},
+		{999999, "999999"},
+		{1000000, "000000"},
+		{1234567, "234567"},
+		{36 * 36 * 36 * 36 * 36 * 36 * 36, "164096"}, // beyond seqWidth
+	}
+
+	for _, tt := range tests {
+		g.seq = tt.seq - 1
+		cid := g.next()
+		if cid.stan != tt.stan {
+			t.Errorf("stan of %d = %s, want %s", tt.seq, cid.stan, tt.stan)
+		}
+	}
+}
+
+func TestCorrelationIdNode(t *testing.T) {
+	for _, node := range []string{"", "GATEWAY01", "GW-1", "GW 1"} {
+		if _, err := newCorrelationIdGenerator(node, time.Now()); err == nil {
+			t.Errorf("node id %q accepted", node)
+		}
+	}
+
+	g, err := newCorrelationIdGenerator("gw01", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := g.next().id; id[:4] != "GW01" {
+		t.Errorf("id %s, want it prefixed with GW01", id)
+	}
+}
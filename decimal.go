@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number, unscaled / 10^scale. Amounts use it
+// instead of float64 so 1234.56 stays 1234.56 from the flat request to the
+// ISO 20022 message.
+type Decimal struct {
+	unscaled int64
+	scale    int
+}
+
+var errDecimalOverflow = errors.New("decimal out of range")
+
+// maxDecimalScale covers the fraction digits of every ISO 20022 amount and rate
+const maxDecimalScale = 18
+
+// currencyScales are the fraction digits of an amount by currency, as used by
+// BI-FAST. Currencies not listed have 2.
+var currencyScales = map[string]int{
+	"IDR": 2,
+	"JPY": 0,
+	"KRW": 0,
+}
+
+func currencyScale(ccy string) int {
+	if s, ok := currencyScales[ccy]; ok {
+		return s
+	}
+	return 2
+}
+
+// parseDecimal reads a plain decimal number such as -1234.56, exponents are
+// not accepted
+func parseDecimal(s string) (Decimal, error) {
+	digits := s
+	negative := false
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+
+	intPart, fracPart := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, fracPart = digits[:i], digits[i+1:]
+	}
+	if intPart == "" && fracPart == "" || len(fracPart) > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	var d Decimal
+	for _, c := range intPart + fracPart {
+		if c < '0' || c > '9' {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		if d.unscaled > (math.MaxInt64-int64(c-'0'))/10 {
+			return Decimal{}, errDecimalOverflow
+		}
+		d.unscaled = d.unscaled*10 + int64(c-'0')
+	}
+	d.scale = len(fracPart)
+	if negative {
+		d.unscaled = -d.unscaled
+	}
+	return d, nil
+}
+
+// parseAmount reads an amount in ccy, it gets the fraction digits of the
+// currency and fails when it has more. Amounts are never signed, ISO 20022
+// amounts have minInclusive 0.
+func parseAmount(s string, ccy string) (Decimal, error) {
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return Decimal{}, fmt.Errorf("amount %s is signed", s)
+	}
+	d, err := parseDecimal(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	scale := currencyScale(ccy)
+	if d.scale > scale {
+		return Decimal{}, fmt.Errorf("%s has more than %d fraction digits for %s", s, scale, ccy)
+	}
+	return d.rescale(scale)
+}
+
+// parsePaymentAmount reads the amount of a payment, see parseAmount, which
+// has to be more than zero
+func parsePaymentAmount(s string, ccy string) (Decimal, error) {
+	d, err := parseAmount(s, ccy)
+	if err != nil {
+		return Decimal{}, err
+	}
+	if d.unscaled == 0 {
+		return Decimal{}, fmt.Errorf("amount %s is zero", s)
+	}
+	return d, nil
+}
+
+// rescale returns d with scale fraction digits, which may not drop digits
+func (d Decimal) rescale(scale int) (Decimal, error) {
+	if scale < d.scale {
+		return Decimal{}, fmt.Errorf("%s has more than %d fraction digits", d, scale)
+	}
+	for d.scale < scale {
+		if d.unscaled > math.MaxInt64/10 || d.unscaled < math.MinInt64/10 {
+			return Decimal{}, errDecimalOverflow
+		}
+		d.unscaled *= 10
+		d.scale++
+	}
+	return d, nil
+}
+
+// Add is the exact sum of d and o
+func (d Decimal) Add(o Decimal) (Decimal, error) {
+	scale := d.scale
+	if o.scale > scale {
+		scale = o.scale
+	}
+	d, err := d.rescale(scale)
+	if err != nil {
+		return Decimal{}, err
+	}
+	o, err = o.rescale(scale)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	sum := d.unscaled + o.unscaled
+	if (o.unscaled > 0 && sum < d.unscaled) || (o.unscaled < 0 && sum > d.unscaled) {
+		return Decimal{}, errDecimalOverflow
+	}
+	return Decimal{unscaled: sum, scale: scale}, nil
+}
+
+// Cmp returns -1, 0 or 1 as d is less than, equal to or greater than o
+func (d Decimal) Cmp(o Decimal) int {
+	// compare digit strings of equal scale, rescaling could overflow
+	a, b := d.digits(), o.digits()
+	for a.scale < b.scale {
+		a.frac += "0"
+		a.scale++
+	}
+	for b.scale < a.scale {
+		b.frac += "0"
+		b.scale++
+	}
+
+	if a.negative != b.negative {
+		if a.negative {
+			return -1
+		}
+		return 1
+	}
+	c := compareDigits(a.int+a.frac, b.int+b.frac)
+	if a.negative {
+		return -c
+	}
+	return c
+}
+
+type decimalDigits struct {
+	negative  bool
+	int, frac string
+	scale     int
+}
+
+func (d Decimal) digits() decimalDigits {
+	s := strconv.FormatInt(d.unscaled, 10)
+	negative := d.unscaled < 0
+	if negative {
+		s = s[1:]
+	}
+	for len(s) <= d.scale {
+		s = "0" + s
+	}
+	return decimalDigits{
+		negative: negative,
+		int:      s[:len(s)-d.scale],
+		frac:     s[len(s)-d.scale:],
+		scale:    d.scale,
+	}
+}
+
+// compareDigits compares two unsigned digit strings of any length
+func compareDigits(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	switch {
+	case len(a) != len(b):
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// String writes d with all its fraction digits and without exponent
+func (d Decimal) String() string {
+	r := d.digits()
+	s := r.int
+	if r.frac != "" {
+		s += "." + r.frac
+	}
+	if r.negative {
+		s = "-" + s
+	}
+	return s
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := parseDecimal(strings.TrimSpace(string(text)))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// UnmarshalJSON takes a Decimal as a string, as it is marshalled, or as a
+// JSON number. The number is read from its digits, never through float64.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := bytes.TrimSpace(data)
+	if bytes.Equal(text, []byte("null")) {
+		return nil
+	}
+	if len(text) > 0 && text[0] == '"' {
+		var s string
+		if err := json.Unmarshal(text, &s); err != nil {
+			return err
+		}
+		text = []byte(s)
+	}
+	return d.UnmarshalText(text)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+// biFastLimit is the most BI-FAST transfers in one transaction, in IDR
+const biFastLimit = "250000000.00"
+
+func TestParsePaymentAmount(t *testing.T) {
+	tests := []struct {
+		in, ccy string
+		want    string
+		wantErr string
+	}{
+		{biFastLimit, "IDR", "250000000.00", ""},
+		{"250000000", "IDR", "250000000.00", ""},
+		{"249999999.99", "IDR", "249999999.99", ""},
+		{"250000000.01", "IDR", "250000000.01", ""},
+		{"0.01", "IDR", "0.01", ""},
+		{"1234.5", "IDR", "1234.50", ""},
+		{"92233720368547758.07", "IDR", "92233720368547758.07", ""}, // the largest int64 in cents
+		{"92233720368547758.08", "IDR", "", "out of range"},
+		{"922337203685477580.7", "IDR", "", "out of range"},
+		{"1500", "JPY", "1500", ""},
+		{"1500.5", "JPY", "", "fraction digits"},
+		{"1.001", "IDR", "", "fraction digits"},
+		{"-5", "IDR", "", "signed"},
+		{"+5", "IDR", "", "signed"},
+		{"0", "IDR", "", "zero"},
+		{"0.00", "IDR", "", "zero"},
+		{"2.5e8", "IDR", "", "invalid decimal"},
+		{"250.000.000", "IDR", "", "invalid decimal"},
+		{"", "IDR", "", "invalid decimal"},
+	}
+
+	for _, tt := range tests {
+		got, err := parsePaymentAmount(tt.in, tt.ccy)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parsePaymentAmount(%q, %s) = %s, %v, want error %q", tt.in, tt.ccy, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got.String() != tt.want {
+			t.Errorf("parsePaymentAmount(%q, %s) = %s, %v, want %s", tt.in, tt.ccy, got, err, tt.want)
+		}
+	}
+}
+
+func TestControlSumLargeAmounts(t *testing.T) {
+	// float64 drifts on sums like this one
+	var amounts []ActiveCurrencyAndAmount
+	for i := 0; i < 1000; i++ {
+		amt, err := parsePaymentAmount("249999999.99", "IDR")
+		if err != nil {
+			t.Fatal(err)
+		}
+		amounts = append(amounts, ActiveCurrencyAndAmount{Value: amt, Ccy: "IDR"})
+	}
+	sum, err := controlSum(amounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sum.String(); got != "249999999990.00" {
+		t.Errorf("controlSum = %s, want 249999999990.00", got)
+	}
+
+	max, _ := parsePaymentAmount("92233720368547758.07", "IDR")
+	one, _ := parsePaymentAmount("0.01", "IDR")
+	if _, err := controlSum([]ActiveCurrencyAndAmount{{Value: max}, {Value: one}}); err == nil {
+		t.Error("controlSum past the int64 range did not fail")
+	}
+}
+
+func TestAmountMarshalling(t *testing.T) {
+	amt, err := parsePaymentAmount(biFastLimit, "IDR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := ActiveCurrencyAndAmount{Value: amt, Ccy: "IDR"}
+
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name                `xml:"Doc"`
+		Amt     ActiveCurrencyAndAmount `xml:"IntrBkSttlmAmt"`
+	}{Amt: v})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<Doc><IntrBkSttlmAmt Ccy="IDR">250000000.00</IntrBkSttlmAmt></Doc>`; string(data) != want {
+		t.Errorf("XML = %s, want %s", data, want)
+	}
+
+	if data, err = json.Marshal(v); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"Value":"250000000.00","Ccy":"IDR"}`; string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+
+	// a JSON number is read from its digits, not through float64
+	var back ActiveCurrencyAndAmount
+	if err := json.Unmarshal([]byte(`{"Value":92233720368547758.07,"Ccy":"IDR"}`), &back); err != nil {
+		t.Fatal(err)
+	}
+	if got := back.Value.String(); got != "92233720368547758.07" {
+		t.Errorf("JSON number read as %s, want 92233720368547758.07", got)
+	}
+}
+
+func TestValidateNegativeAmount(t *testing.T) {
+	msg, err := mapCreditTransfer(creditTransferSample(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	negative, err := parseDecimal("-5.00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf[0].IntrBkSttlmAmt.Value = negative
+
+	err = msg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "CdtTrfTxInf[0].IntrBkSttlmAmt: -5.00 is negative") {
+		t.Errorf("Validate = %v, want the negative IntrBkSttlmAmt reported", err)
+	}
+
+	req := creditTransferSample(t)
+	req.Interbanksettlementamount = "-5"
+	if _, err := mapCreditTransfer(req); err == nil {
+		t.Error("credit transfer of -5 mapped without error")
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// requestKinds are the flat specs a channel may send
+var requestKinds = []requestKind{
+	kindCreditTransfer,
+	kindCreditTransferProxy,
+	kindAccountEnquiry,
+	kindFICreditTransfer,
+	kindCreditTransferReturn,
+}
+
+// parseRequestKind finds the kind named s, ignoring case
+func parseRequestKind(s string) (requestKind, bool) {
+	for _, k := range requestKinds {
+		if strings.EqualFold(s, string(k)) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+var errUnrecognizedRequest = errors.New("request matches none of the flat specs")
+
+// requestError is a channel request that was not produced. Kind is empty
+// when the request could not be told apart.
+type requestError struct {
+	Kind requestKind
+	Err  error
+}
+
+func (e *requestError) Error() string {
+	if e.Kind == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %v", e.Kind, e.Err)
+}
+
+// dispatchRequest tells what flat spec frame is written in and returns the
+// JSON object to decode. The kind is taken, first match wins, from
+//
+//	a header in front of the object   PACS008AccEnq {"EndToEndID": ...}
+//	the msgType field of the object   {"msgType": "PACS008AccEnq", ...}
+//	the keys only one spec has        CustomerAccountNumber
+//
+// Keys are matched as by foldKey. Header and msgType field must agree when
+// both are given. The json framing reads one JSON value per frame and so
+// cannot carry a header.
+func dispatchRequest(frame []byte) (requestKind, []byte, error) {
+	header, payload := splitFrameHeader(frame)
+
+	var headerKind requestKind
+	if header != "" {
+		k, ok := parseRequestKind(header)
+		if !ok {
+			return "", nil, &requestError{Err: fmt.Errorf("unknown %s %q in frame header", msgTypeHeader, header)}
+		}
+		headerKind = k
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return headerKind, nil, &requestError{Kind: headerKind, Err: fmt.Errorf("request is not a JSON object: %v", err)}
+	}
+	keys := make(map[string]json.RawMessage, len(fields))
+	for key, raw := range fields {
+		keys[foldKey(key)] = raw
+	}
+
+	if raw, ok := keys[strings.ToLower(msgTypeHeader)]; ok {
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil {
+			return headerKind, nil, &requestError{Kind: headerKind, Err: fmt.Errorf("%s is not a string", msgTypeHeader)}
+		}
+		k, ok := parseRequestKind(name)
+		if !ok {
+			return headerKind, nil, &requestError{Kind: headerKind, Err: fmt.Errorf("unknown %s %q", msgTypeHeader, name)}
+		}
+		if headerKind != "" && headerKind != k {
+			return headerKind, nil, &requestError{Kind: headerKind, Err: fmt.Errorf("%s %s differs from frame header", msgTypeHeader, k)}
+		}
+		return k, payload, nil
+	}
+	if headerKind != "" {
+		return headerKind, payload, nil
+	}
+
+	k, ok := detectRequestKind(keys)
+	if !ok {
+		return "", nil, &requestError{Err: errUnrecognizedRequest}
+	}
+	return k, payload, nil
+}
+
+// splitFrameHeader splits what comes before the JSON object of frame off as
+// its header
+func splitFrameHeader(frame []byte) (string, []byte) {
+	i := bytes.IndexByte(frame, '{')
+	if i <= 0 {
+		return "", frame
+	}
+	header := strings.TrimSpace(string(frame[:i]))
+	header = strings.TrimSpace(strings.TrimSuffix(header, ":"))
+	return header, frame[i:]
+}
+
+// detectRequestKind tells the flat specs apart by the keys only one of them
+// has, keys folded by foldKey. A credit transfer needs at least its creditor
+// account, an object without any of these keys is no request.
+func detectRequestKind(keys map[string]json.RawMessage) (requestKind, bool) {
+	if _, ok := keys["customeraccountnumber"]; ok {
+		return kindAccountEnquiry, true
+	}
+	if _, ok := keys["debtorinstitutionid"]; ok {
+		return kindFICreditTransfer, true
+	}
+	if _, ok := keys["originalendtoendid"]; ok {
+		return kindCreditTransferReturn, true
+	}
+	// with the proxy left empty mapCreditTransferWithProxy takes the
+	// creditor account, the spec still has to be PACS008CTwProxy for strict
+	// decoding to accept its keys
+	if _, ok := keys["proxycreditoraccountid"]; ok {
+		return kindCreditTransferProxy, true
+	}
+	if _, ok := keys["creditoraccountid"]; ok {
+		return kindCreditTransfer, true
+	}
+	return "", false
+}
+
+// requestTopic is the Kafka topic the requests of kind are produced to
+func (k KafkaConfig) requestTopic(kind requestKind) string {
+	if topic := k.Topics[string(kind)]; topic != "" {
+		return topic
+	}
+	return k.RequestTopic
+}
+
+// requestTopics are all topics requests may be produced to, each once
+func (k KafkaConfig) requestTopics() []string {
+	topics := []string{k.RequestTopic}
+	seen := map[string]bool{k.RequestTopic: true}
+	for _, kind := range requestKinds {
+		if topic := k.requestTopic(kind); !seen[topic] {
+			seen[topic] = true
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+const (
+	// reasons of the ChannelErrorResponse of a request without usable response
+	reasonNotSent    = "AB07" // not produced or not delivered, BI-FAST never saw it
+	reasonNoResponse = "AB06" // produced, BI-FAST did not answer in time
+)
+
+// requestErrorResponse is the reply to a request that was not produced
+func requestErrorResponse(err error) []byte {
+	var kind requestKind
+	if re, ok := err.(*requestError); ok {
+		kind, err = re.Kind, re.Err
+	}
+	return channelErrorResponse(kind, "RJCT", reasonInvalidFormat, err)
+}
+
+// channelErrorResponse is the ChannelErrorResponse of a request of kind
+// that failed with err
+func channelErrorResponse(kind requestKind, status string, reason string, err error) []byte {
+	res := ChannelErrorResponse{
+		Msgtype:    string(kind),
+		Status:     status,
+		Reasoncode: reason,
+		Reasontext: statusReasons[reason],
+		Errortext:  err.Error(),
+	}
+	// only strings, it always marshals
+	data, _ := json.Marshal(res)
+	return data
+}
+
+// unsettledStatus is the status of a request of kind that was produced but
+// got no usable response. A credit transfer may still be carried out and is
+// pending until reconciliation, an account enquiry is simply rejected.
+func unsettledStatus(kind requestKind) string {
+	if kind == kindAccountEnquiry {
+		return "RJCT"
+	}
+	return "PDNG"
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const maxFrameSize = 1 << 20 // 1 MiB, far above any PACS message
+
+var errFrameTooLarge = errors.New("frame exceeds maximum size")
+
+// Framer splits the byte stream of a channel connection into messages and
+// writes responses back with the same framing
+type Framer interface {
+	ReadFrame(r *bufio.Reader) ([]byte, error)
+	WriteFrame(w io.Writer, msg []byte) error
+}
+
+// newFramer returns the framer registered under name, see `framers`
+func newFramer(name string) (Framer, error) {
+	f, ok := framers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown framing %q", name)
+	}
+	return f, nil
+}
+
+// defaultFraming of a listener, reads pretty-printed JSON as one request
+// like the raw read before framing did
+const defaultFraming = "json"
+
+var framers = map[string]Framer{
+	"len2":    binaryLengthFramer{size: 2},
+	"len4":    binaryLengthFramer{size: 4},
+	"ascii4":  asciiLengthFramer{digits: 4},
+	"newline": delimiterFramer{delim: '\n'},
+	"etx":     delimiterFramer{delim: 0x03},
+	"json":    jsonStreamFramer{},
+}
+
+// binaryLengthFramer prefixes every message with its length as a big-endian
+// unsigned integer of 2 or 4 bytes
+type binaryLengthFramer struct {
+	size int
+}
+
+func (f binaryLengthFramer) ReadFrame(r *bufio.Reader) ([]byte, error) {
+	prefix := make([]byte, f.size)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, err
+	}
+
+	var n uint64
+	if f.size == 2 {
+		n = uint64(binary.BigEndian.Uint16(prefix))
+	} else {
+		n = uint64(binary.BigEndian.Uint32(prefix))
+	}
+	return readBody(r, n)
+}
+
+func (f binaryLengthFramer) WriteFrame(w io.Writer, msg []byte) error {
+	max := uint64(1)<<(8*uint(f.size)) - 1
+	if uint64(len(msg)) > max || len(msg) > maxFrameSize {
+		return errFrameTooLarge
+	}
+
+	frame := make([]byte, f.size+len(msg))
+	if f.size == 2 {
+		binary.BigEndian.PutUint16(frame, uint16(len(msg)))
+	} else {
+		binary.BigEndian.PutUint32(frame, uint32(len(msg)))
+	}
+	copy(frame[f.size:], msg)
+
+	_, err := w.Write(frame)
+	return err
+}
+
+// asciiLengthFramer prefixes every message with its length as zero padded
+// ASCII digits, e.g. "0042{...}"
+type asciiLengthFramer struct {
+	digits int
+}
+
+func (f asciiLengthFramer) ReadFrame(r *bufio.Reader) ([]byte, error) {
+	prefix := make([]byte, f.digits)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, err
+	}
+
+	n, err := strconv.ParseUint(string(prefix), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid length header %q", prefix)
+	}
+	return readBody(r, n)
+}
+
+func (f asciiLengthFramer) WriteFrame(w io.Writer, msg []byte) error {
+	header := fmt.Sprintf("%0*d", f.digits, len(msg))
+	if len(header) > f.digits || len(msg) > maxFrameSize {
+		return errFrameTooLarge
+	}
+
+	_, err := w.Write(append([]byte(header), msg...))
+	return err
+}
+
+// delimiterFramer ends every message with a single delimiter byte, e.g.
+// newline or ETX
+type delimiterFramer struct {
+	delim byte
+}
+
+func (f delimiterFramer) ReadFrame(r *bufio.Reader) ([]byte, error) {
+	for {
+		msg, err := readUntil(r, f.delim)
+		if err != nil {
+			return nil, err
+		}
+
+		// skip keep-alives and the CR of CRLF terminated lines
+		msg = bytes.TrimRight(msg, "\r\x00")
+		if len(bytes.TrimSpace(msg)) > 0 {
+			return msg, nil
+		}
+	}
+}
+
+func (f delimiterFramer) WriteFrame(w io.Writer, msg []byte) error {
+	_, err := w.Write(append(append([]byte{}, msg...), f.delim))
+	return err
+}
+
+// jsonStreamFramer reads back-to-back JSON objects without any separator,
+// a message ends where its outermost object closes
+type jsonStreamFramer struct{}
+
+func (jsonStreamFramer) ReadFrame(r *bufio.Reader) ([]byte, error) {
+	// skip anything between objects (whitespace, newlines, NULs)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == '{' {
+			break
+		}
+		if !isJSONSpace(b) && b != 0 {
+			return nil, fmt.Errorf("unexpected %q outside of JSON object", b)
+		}
+	}
+
+	msg := []byte{'{'}
+	depth := 1
+	inString, escaped := false, false
+	for depth > 0 {
+		b, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		if len(msg) >= maxFrameSize {
+			return nil, errFrameTooLarge
+		}
+		msg = append(msg, b)
+
+		switch {
+		case escaped:
+			escaped = false
+		case inString && b == '\\':
+			escaped = true
+		case b == '"':
+			inString = !inString
+		case inString:
+		case b == '{' || b == '[':
+			depth++
+		case b == '}' || b == ']':
+			depth--
+		}
+	}
+	return msg, nil
+}
+
+func (jsonStreamFramer) WriteFrame(w io.Writer, msg []byte) error {
+	_, err := w.Write(msg)
+	return err
+}
+
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
+
+func readBody(r *bufio.Reader, n uint64) ([]byte, error) {
+	if n > maxFrameSize {
+		return nil, errFrameTooLarge
+	}
+
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return msg, nil
+}
+
+func readUntil(r *bufio.Reader, delim byte) ([]byte, error) {
+	var msg []byte
+	for {
+		chunk, err := r.ReadSlice(delim)
+		if len(msg)+len(chunk) > maxFrameSize {
+			return nil, errFrameTooLarge
+		}
+		msg = append(msg, chunk...)
+
+		switch err {
+		case nil:
+			return msg[:len(msg)-1], nil
+		case bufio.ErrBufferFull:
+			continue
+		default:
+			return nil, err
+		}
+	}
+}
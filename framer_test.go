@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// readFrames reads the frames of stream handing the framer one byte per
+// read, so every frame arrives split. It stops at the first error, io.EOF
+// after the last frame gives nil.
+func readFrames(f Framer, stream []byte) ([]string, error) {
+	// 16 is the smallest buffer bufio allows, smaller than any frame here
+	r := bufio.NewReaderSize(iotest.OneByteReader(bytes.NewReader(stream)), 16)
+	var frames []string
+	for {
+		msg, err := f.ReadFrame(r)
+		if err == io.EOF {
+			return frames, nil
+		}
+		if err != nil {
+			return frames, err
+		}
+		frames = append(frames, string(msg))
+	}
+}
+
+func TestFramerRoundTrip(t *testing.T) {
+	msgs := []string{
+		`{"msgType":"PACS008AccEnq","endToEndId":"E1"}`,
+		`{"a":"}{","b":"\"x\\"}`,
+		`{"pad":"` + strings.Repeat("x", 300) + `"}`, // over the 256 bytes of len2's first byte
+	}
+
+	for name, f := range framers {
+		t.Run(name, func(t *testing.T) {
+			var stream bytes.Buffer
+			for _, msg := range msgs {
+				if err := f.WriteFrame(&stream, []byte(msg)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := readFrames(f, stream.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(got, "|") != strings.Join(msgs, "|") {
+				t.Errorf("read %q, want %q", got, msgs)
+			}
+		})
+	}
+}
+
+func TestFramerRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		framing string
+		stream  string
+		want    []string
+		wantErr string
+	}{
+		{"len2", "len2", "\x00\x02{}\x00\x07{\"a\":1}", []string{"{}", `{"a":1}`}, ""},
+		{"len2 truncated", "len2", "\x00\x09{\"a\":1}", nil, "unexpected EOF"},
+		{"len4", "len4", "\x00\x00\x00\x02{}", []string{"{}"}, ""},
+		{"ascii4", "ascii4", "0002{}0007{\"a\":1}", []string{"{}", `{"a":1}`}, ""},
+		{"ascii4 invalid length", "ascii4", "00x2{}", nil, "invalid length header"},
+		{"ascii4 truncated", "ascii4", "0009{}", nil, "unexpected EOF"},
+		{"newline", "newline", "{\"a\":1}\n{}\n", []string{`{"a":1}`, "{}"}, ""},
+		{"newline CRLF and keep-alives", "newline", "\n\r\n{}\r\n\x00\n{\"a\":1}\r\n", []string{"{}", `{"a":1}`}, ""},
+		{"newline without last delimiter", "newline", "{}\n{\"a\":", []string{"{}"}, ""},
+		{"etx", "etx", "{\"a\":\n1}\x03{}\x03", []string{"{\"a\":\n1}", "{}"}, ""},
+		{"json back to back", "json", `{"a":1}{"b":2}`, []string{`{"a":1}`, `{"b":2}`}, ""},
+		{"json between objects", "json", " \r\n{\"a\":1}\n\x00\t{}\n", []string{`{"a":1}`, "{}"}, ""},
+		{"json pretty printed", "json", "{\n  \"a\": {\n    \"b\": [1, {}]\n  }\n}", []string{"{\n  \"a\": {\n    \"b\": [1, {}]\n  }\n}"}, ""},
+		{"json braces in string", "json", `{"a":"}]{"}{}`, []string{`{"a":"}]{"}`, "{}"}, ""},
+		{"json escaped quote", "json", `{"a":"\"}"}{}`, []string{`{"a":"\"}"}`, "{}"}, ""},
+		{"json escaped backslash", "json", `{"a":"\\"}{"b":"\\\""}`, []string{`{"a":"\\"}`, `{"b":"\\\""}`}, ""},
+		{"json unicode escape", "json", `{"a":"\u0022}"}`, []string{`{"a":"\u0022}"}`}, ""},
+		{"json outside object", "json", `{}x{}`, []string{"{}"}, `unexpected 'x' outside of JSON object`},
+		{"json truncated", "json", `{"a":{"b":1}`, nil, "unexpected EOF"},
+		{"json unterminated string", "json", `{"a":"}`, nil, "unexpected EOF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newFramer(tt.framing)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := readFrames(f, []byte(tt.stream))
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("read %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// no framing reads or writes more than maxFrameSize, whatever the peer sends
+func TestFramerMaxSize(t *testing.T) {
+	over := func(suffix string) []byte {
+		return []byte(`{"a":"` + strings.Repeat("x", maxFrameSize) + `"}` + suffix)
+	}
+	reads := map[string][]byte{
+		"len4":    []byte("\x00\x10\x00\x01"),
+		"newline": over("\n"),
+		"etx":     over("\x03"),
+		"json":    over(""),
+	}
+	for name, stream := range reads {
+		t.Run("read "+name, func(t *testing.T) {
+			f, _ := newFramer(name)
+			if _, err := f.ReadFrame(bufio.NewReader(bytes.NewReader(stream))); err != errFrameTooLarge {
+				t.Errorf("err = %v, want %v", err, errFrameTooLarge)
+			}
+		})
+	}
+
+	writes := map[string]int{
+		"len2":   1 << 16,
+		"len4":   maxFrameSize + 1,
+		"ascii4": 10000,
+	}
+	for name, n := range writes {
+		t.Run("write "+name, func(t *testing.T) {
+			f, _ := newFramer(name)
+			if err := f.WriteFrame(ioutil.Discard, make([]byte, n)); err != errFrameTooLarge {
+				t.Errorf("err = %v, want %v", err, errFrameTooLarge)
+			}
+			if err := f.WriteFrame(ioutil.Discard, make([]byte, n-1)); err != nil {
+				t.Errorf("%d bytes: %v", n-1, err)
+			}
+		})
+	}
+
+	if _, err := newFramer("len3"); err == nil {
+		t.Error("unknown framing accepted")
+	}
+}
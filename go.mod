@@ -2,4 +2,10 @@ module local/juni/20210612/netChannel
 
 go 1.15
 
-require github.com/confluentinc/confluent-kafka-go v1.7.0
+require (
+	github.com/beevik/etree v1.1.0
+	github.com/confluentinc/confluent-kafka-go v1.7.0
+	github.com/russellhaering/goxmldsig v1.4.0
+	golang.org/x/crypto v0.0.0-20210322153248-0c34fe9e7dc2
+	gopkg.in/yaml.v2 v2.4.0
+)
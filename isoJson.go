@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// the JSON form of the model follows the XML element names, amounts are
+// {"Value":"1234.56","Ccy":"IDR"} and envelopes carry their XML as a string
+
+func (e SignatureEnvelope) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Item)
+}
+
+func (e *SignatureEnvelope) UnmarshalJSON(data []byte) error {
+	return unmarshalEnvelope(data, &e.Item)
+}
+
+func (e SupplementaryDataEnvelope1) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Item)
+}
+
+func (e *SupplementaryDataEnvelope1) UnmarshalJSON(data []byte) error {
+	return unmarshalEnvelope(data, &e.Item)
+}
+
+func unmarshalEnvelope(data []byte, item *string) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*item = ""
+		return nil
+	}
+	if err := json.Unmarshal(data, item); err != nil {
+		return fmt.Errorf("envelope is not an XML string: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// JSON to struct to XML, and back, for every Document kind
+func TestBusMsgJSONRoundTrip(t *testing.T) {
+	for k, msg := range goldenMessages(t) {
+		t.Run(string(k), func(t *testing.T) {
+			data, err := json.Marshal(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, bad := range []string{",chardata", ",attr", ",any"} {
+				if bytes.Contains(data, []byte(bad)) {
+					t.Errorf("JSON has a key with %q: %s", bad, data)
+				}
+			}
+
+			parsed, err := parseBusMsgJSON(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			xmlData, err := marshalBusMsgXML(parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := readTestdata(t, string(k)+".xml")
+			if !bytes.Equal(xmlData, golden) {
+				t.Fatalf("XML of the parsed JSON differs from %s:\n%s", goldenFile(k), xmlData)
+			}
+
+			fromXML, err := parseBusMsgXML(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, err := json.Marshal(fromXML)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(again, data) {
+				t.Errorf("JSON of %s differs:\n got %s\nwant %s", goldenFile(k), again, data)
+			}
+		})
+	}
+}
+
+func TestBusMsgJSONAmountForms(t *testing.T) {
+	msg := goldenMessages(t)[kindPacs008]
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const amount = `"IntrBkSttlmAmt":{"Value":"1234.56","Ccy":"IDR"}`
+	if !bytes.Contains(data, []byte(amount)) {
+		t.Fatalf("JSON has no %s: %s", amount, data)
+	}
+
+	// the adapter may send amounts as JSON numbers
+	numbers := strings.Replace(string(data), `"Value":"1234.56"`, `"Value":1234.56`, -1)
+	numbers = strings.Replace(numbers, `"CtrlSum":"1234.56"`, `"CtrlSum":1234.56`, -1)
+	if numbers == string(data) {
+		t.Fatal("no amount replaced by a number")
+	}
+	parsed, err := parseBusMsgJSON([]byte(numbers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	xmlData, err := marshalBusMsgXML(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(xmlData, readTestdata(t, string(kindPacs008)+".xml")) {
+		t.Errorf("XML of the JSON with number amounts differs from %s:\n%s", goldenFile(kindPacs008), xmlData)
+	}
+
+	for _, bad := range []string{`"1.2e3"`, `1.2e3`, `"12,34"`, `true`} {
+		invalid := strings.Replace(string(data), `"Value":"1234.56"`, `"Value":`+bad, 1)
+		if _, err := parseBusMsgJSON([]byte(invalid)); err == nil {
+			t.Errorf("amount %s parsed without error", bad)
+		}
+	}
+}
+
+func TestEnvelopeJSON(t *testing.T) {
+	env := SignatureEnvelope{Item: `<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#"/>`}
+	data, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"\u003cds:Signature xmlns:ds=\"http://www.w3.org/2000/09/xmldsig#\"/\u003e"`; string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+
+	var back SignatureEnvelope
+	if err := json.Unmarshal(data, &back); err != nil || back != env {
+		t.Errorf("back = %+v, %v, want %+v", back, err, env)
+	}
+	if err := json.Unmarshal([]byte(`{"Item":"x"}`), &back); err == nil {
+		t.Error("envelope object parsed without error")
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// message definitions of the Document choice, the XML namespace of a
+// Document is the message definition prefixed with isoNamespacePrefix
+const (
+	pacs008MsgDefIdr = "pacs.008.001.08"
+	pacs002MsgDefIdr = "pacs.002.001.10"
+	pacs009MsgDefIdr = "pacs.009.001.09"
+	pacs028MsgDefIdr = "pacs.028.001.04"
+
+	isoNamespacePrefix = "urn:iso:std:iso:20022:tech:xsd:"
+	head001Namespace   = isoNamespacePrefix + "head.001.001.01"
+)
+
+// MarshalXML writes the Document in the namespace of its message, with only
+// the populated member in it
+func (d Document) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := d.checkChoice(); err != nil {
+		return err
+	}
+	k := d.Kind()
+
+	start = xml.StartElement{Name: xml.Name{Space: k.namespace(), Local: "Document"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeElement(d.message(k), xml.StartElement{Name: xml.Name{Local: k.element()}}); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML reads the member matching the namespace of the Document
+func (d *Document) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var k documentKind
+	for _, kind := range documentKinds {
+		if kind.namespace() == start.Name.Space {
+			k = kind
+		}
+	}
+	if k == "" {
+		return fmt.Errorf("Document with unknown namespace %q", start.Name.Space)
+	}
+
+	*d = Document{}
+	found := false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != k.element() || found {
+				return fmt.Errorf("unexpected %s in %s Document", t.Name.Local, k)
+			}
+			if err := dec.DecodeElement(d.newMessage(k), &t); err != nil {
+				return err
+			}
+			found = true
+
+		case xml.EndElement:
+			if !found {
+				return fmt.Errorf("%s Document without %s", k, k.element())
+			}
+			return nil
+		}
+	}
+}
+
+// marshalBusMsgXML writes msg as ISO 20022 XML, AppHdr in the head.001
+// namespace followed by the Document in the namespace of its message
+func marshalBusMsgXML(msg BusMsg) ([]byte, error) {
+	out, err := xml.MarshalIndent(msg, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// parseBusMsgXML reads ISO 20022 XML, the message type is detected from the
+// Document namespace and has to match AppHdr.MsgDefIdr
+func parseBusMsgXML(data []byte) (BusMsg, error) {
+	var msg BusMsg
+	if err := xml.Unmarshal(data, &msg); err != nil {
+		return BusMsg{}, err
+	}
+	if err := msg.checkMsgDefIdr(); err != nil {
+		return BusMsg{}, err
+	}
+	return msg, nil
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// testHeaders sets headers to a builder whose clock stands at now, with a
+// fresh daily sequence
+func testHeaders(t *testing.T, now time.Time) *appHdrBuilder {
+	t.Helper()
+	seq, err := openDailySequence(filepath.Join(t.TempDir(), "netChannel.seq"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers = newAppHdrBuilder(seq, "RB")
+	headers.now = func() time.Time { return now }
+	return headers
+}
+
+// goldenMessages are one message of every Document kind, built from the
+// samples the way the gateway builds them
+func goldenMessages(t *testing.T) map[documentKind]BusMsg {
+	t.Helper()
+	testHeaders(t, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	ct, err := mapCreditTransfer(creditTransferSample(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fiReq PACS009FICreditTransfer
+	if err := decodeFlatSpec(readSample(t, "PACS009FICreditTransfer"), &fiReq, true); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := mapFICreditTransfer(fiReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rpt, err := statusReport(ct, "RJCT", "AC03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := headers.complete(&rpt, creditTransferTxType); err != nil {
+		t.Fatal(err)
+	}
+
+	inq, err := statusInquiry(ct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := headers.complete(&inq, creditTransferTxType); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := map[documentKind]BusMsg{kindPacs008: ct, kindPacs009: fi, kindPacs002: rpt, kindPacs028: inq}
+	for k, msg := range msgs {
+		if err := msg.Validate(); err != nil {
+			t.Fatalf("%s: %v", k, err)
+		}
+	}
+	return msgs
+}
+
+func goldenFile(k documentKind) string {
+	return filepath.Join("testdata", string(k)+".xml")
+}
+
+func TestBusMsgXMLGolden(t *testing.T) {
+	for k, msg := range goldenMessages(t) {
+		t.Run(string(k), func(t *testing.T) {
+			got, err := marshalBusMsgXML(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *update {
+				if err := ioutil.WriteFile(goldenFile(k), got, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want := readTestdata(t, string(k)+".xml")
+			if !bytes.Equal(got, want) {
+				t.Fatalf("marshalBusMsgXML differs from %s:\n%s", goldenFile(k), got)
+			}
+
+			parsed, err := parseBusMsgXML(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pk := parsed.Document.Kind(); pk != k {
+				t.Fatalf("parsed Document is %s, want %s", pk, k)
+			}
+			again, err := marshalBusMsgXML(parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(again, want) {
+				t.Errorf("%s parsed and marshalled again differs:\n%s", goldenFile(k), again)
+			}
+		})
+	}
+}
+
+func TestParseBusMsgXMLRejects(t *testing.T) {
+	pacs008 := string(readTestdata(t, string(kindPacs008)+".xml"))
+	tests := map[string]string{
+		"unknown namespace": replaceOnce(pacs008, pacs008MsgDefIdr+`"`, `pacs.008.001.99"`),
+		"wrong MsgDefIdr":   replaceOnce(pacs008, "<MsgDefIdr>"+pacs008MsgDefIdr, "<MsgDefIdr>"+pacs009MsgDefIdr),
+		"wrong member":      replaceOnce(replaceOnce(pacs008, "<FIToFICstmrCdtTrf>", "<FICdtTrf>"), "</FIToFICstmrCdtTrf>", "</FICdtTrf>"),
+	}
+	for name, data := range tests {
+		if data == pacs008 {
+			t.Fatalf("%s: golden pacs.008 not changed", name)
+		}
+		if _, err := parseBusMsgXML([]byte(data)); err == nil {
+			t.Errorf("%s: parsed without error", name)
+		}
+	}
+}
+
+func replaceOnce(s string, old string, new string) string {
+	return string(bytes.Replace([]byte(s), []byte(old), []byte(new), 1))
+}
+
+// emptyElement is an element without content, which an optional aggregate
+// left unset must not produce
+var emptyElement = regexp.MustCompile(`<([A-Za-z]+)[^>]*(/>|></[A-Za-z]+>)`)
+
+// mandatoryEmpty may be written empty, Dbtr and Cdtr of an account enquiry
+// are mandatory but have only optional children
+var mandatoryEmpty = map[string]bool{"Dbtr": true, "Cdtr": true}
+
+func emptyElements(data []byte) []string {
+	var empty []string
+	for _, m := range emptyElement.FindAllSubmatch(data, -1) {
+		if !mandatoryEmpty[string(m[1])] {
+			empty = append(empty, string(m[0]))
+		}
+	}
+	return empty
+}
+
+// the three samples marshal without empty elements and well below the size
+// they had with every optional aggregate written
+func TestSampleXMLSize(t *testing.T) {
+	testHeaders(t, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC))
+	tests := []struct {
+		kind    requestKind
+		maxSize int
+	}{
+		{kindCreditTransfer, 4096},
+		{kindCreditTransferProxy, 4096},
+		{kindAccountEnquiry, 2048},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			msg, err := mapChannelRequest(tt.kind, readSample(t, string(tt.kind)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := headers.complete(&msg, tt.kind.txType()); err != nil {
+				t.Fatal(err)
+			}
+			if err := msg.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			data, err := marshalBusMsgXML(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Logf("%d bytes", len(data))
+
+			if len(data) > tt.maxSize {
+				t.Errorf("%d bytes, want at most %d", len(data), tt.maxSize)
+			}
+			if empty := emptyElements(data); len(empty) > 0 {
+				t.Errorf("empty elements %s in\n%s", strings.Join(empty, ", "), data)
+			}
+		})
+	}
+}
+
+// a request with only the mandatory fields has only mandatory aggregates
+func TestMinimalCreditTransferXML(t *testing.T) {
+	testHeaders(t, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC))
+	msg, err := mapCreditTransfer(PACS008CreditTransfer{
+		Creationdatetime:          "2021-03-01T19:00:00",
+		Interbanksettlementamount: "1234.56",
+		Currencycode:              "IDR",
+		Debtorbankid:              "INDOIDJA",
+		Creditorbankid:            "CENAIDJA",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := headers.complete(&msg, creditTransferTxType); err != nil {
+		t.Fatal(err)
+	}
+	if err := msg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := marshalBusMsgXML(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if empty := emptyElements(data); len(empty) > 0 {
+		t.Errorf("empty elements %s in\n%s", strings.Join(empty, ", "), data)
+	}
+	for _, absent := range []string{"PmtTpInf", "RmtInf", "SplmtryData", "DbtrAcct", "CdtrAcct", "<Id>"} {
+		if bytes.Contains(data, []byte(absent)) {
+			t.Errorf("%s in minimal pacs.008:\n%s", absent, data)
+		}
+	}
+	for _, present := range []string{"<Dbtr>", "<DbtrAgt>", "<CdtrAgt>", "<Cdtr>", "<SttlmMtd>CLRG</SttlmMtd>", "<ChrgBr>SLEV</ChrgBr>"} {
+		if !bytes.Contains(data, []byte(present)) {
+			t.Errorf("no %s in minimal pacs.008:\n%s", present, data)
+		}
+	}
+}
+
+func TestPaymentTypeInformationOmitted(t *testing.T) {
+	if p := paymentTypeInformation("", nil); p != nil {
+		t.Errorf("paymentTypeInformation without channel and purpose = %+v, want nil", p)
+	}
+
+	var req PACS009FICreditTransfer
+	if err := decodeFlatSpec(readSample(t, "PACS009FICreditTransfer"), &req, true); err != nil {
+		t.Fatal(err)
+	}
+	req.Paymentchannelid, req.Categorypurpose = "", ""
+	msg, err := mapFICreditTransfer(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := msg.Document.FICdtTrf.CdtTrfTxInf[0].PmtTpInf; p != nil {
+		t.Errorf("pacs.009 PmtTpInf = %+v, want nil", p)
+	}
+}
@@ -12,7 +12,7 @@ type PACS008AccEnq struct {
 	Chargebearer              string `json:"ChargeBearer,omitempty"`
 	Debtorbankid              string `json:"DebtorBankID,omitempty"`
 	Creditorbankid            string `json:"CreditorBankID,omitempty"`
-	Customeraccountnumbera    string `json:"CustomerAccountNumbera,omitempty"`
+	Customeraccountnumber     string `json:"CustomerAccountNumber,omitempty"`
 }
 
 type PACS008CreditTransfer struct {
@@ -39,7 +39,7 @@ type PACS008CreditTransfer struct {
 	Creditorprivateid                 string `json:"creditorPrivateId,omitempty"`
 	Creditoraccountid                 string `json:"creditorAccountId,omitempty"`
 	Creditoraccounttype               string `json:"creditorAccountType,omitempty"`
-	Remmitanceinformationunstructured string `json:"remmitanceInformationunstructured,omitempty"`
+	Remittanceinformationunstructured string `json:"remittanceInformationUnstructured,omitempty"`
 	Debtortype                        string `json:"DebtorType,omitempty"`
 	Debtorresidentstatus              string `json:"DebtorResidentStatus,omitempty"`
 	Debtortownname                    string `json:"DebtorTownName,omitempty"`
@@ -74,7 +74,7 @@ type PACS008CTwProxy struct {
 	Creditoraccounttype               string `json:"creditorAccountType,omitempty"`
 	Proxycreditoraccounttype          string `json:"proxyCreditorAccountType,omitempty"`
 	Proxycreditoraccountid            string `json:"proxyCreditorAccountId,omitempty"`
-	Remmitanceinformationunstructured string `json:"remmitanceInformationunstructured,omitempty"`
+	Remittanceinformationunstructured string `json:"remittanceInformationUnstructured,omitempty"`
 	Debtortype                        string `json:"DebtorType,omitempty"`
 	Debtorresidentstatus              string `json:"DebtorResidentStatus,omitempty"`
 	Debtortownname                    string `json:"DebtorTownName,omitempty"`
@@ -82,3 +82,90 @@ type PACS008CTwProxy struct {
 	Creditorresidentstatus            string `json:"CreditorResidentStatus,omitempty"`
 	Creditortownname                  string `json:"CreditorTownName,omitempty"`
 }
+
+// PACS008AccEnqResponse is what the channel gets back for a PACS008AccEnq,
+// taken from the pacs.002 answering the account enquiry
+type PACS008AccEnqResponse struct {
+	Endtoendid             string `json:"EndToEndID,omitempty"`
+	Status                 string `json:"Status,omitempty"`
+	Reasoncode             string `json:"ReasonCode,omitempty"`
+	Creditorname           string `json:"CreditorName,omitempty"`
+	Customeraccountnumber  string `json:"CustomerAccountNumber,omitempty"`
+	Creditoraccounttype    string `json:"CreditorAccountType,omitempty"`
+	Creditortype           string `json:"CreditorType,omitempty"`
+	Creditorresidentstatus string `json:"CreditorResidentStatus,omitempty"`
+	Creditortownname       string `json:"CreditorTownName,omitempty"`
+}
+
+// PACS002StatusResponse is what the channel gets back for a credit transfer,
+// taken from the pacs.002 reporting its status
+type PACS002StatusResponse struct {
+	Endtoendid               string `json:"endToEndId,omitempty"`
+	Transactionid            string `json:"transactionId,omitempty"`
+	Originalmessageid        string `json:"originalMessageId,omitempty"`
+	Status                   string `json:"status,omitempty"`
+	Reasoncode               string `json:"reasonCode,omitempty"`
+	Reasontext               string `json:"reasonText,omitempty"`
+	Accountservicerreference string `json:"accountServicerReference,omitempty"`
+	Creditortype             string `json:"CreditorType,omitempty"`
+	Creditorresidentstatus   string `json:"CreditorResidentStatus,omitempty"`
+	Creditortownname         string `json:"CreditorTownName,omitempty"`
+}
+
+// PACS009FICreditTransfer is a transfer between participants on their own
+// account, e.g. treasury or liquidity. The underlying* fields describe the
+// customer credit transfer it covers, if any.
+type PACS009FICreditTransfer struct {
+	Messageid                                   string `json:"messageId,omitempty"`
+	Creationdatetime                            string `json:"creationDateTime,omitempty"`
+	Numberoftransaction                         string `json:"numberOfTransaction,omitempty"`
+	Settlementmethod                            string `json:"settlementMethod,omitempty"`
+	Interbanksettlementdate                     string `json:"interBankSettlementDate,omitempty"`
+	Endtoendid                                  string `json:"endToEndId,omitempty"`
+	Transactionid                               string `json:"transactionId,omitempty"`
+	Paymentchannelid                            string `json:"paymentChannelId,omitempty"`
+	Categorypurpose                             string `json:"categoryPurpose,omitempty"`
+	Interbanksettlementamount                   string `json:"InterBankSettlementAmount,omitempty"`
+	Currencycode                                string `json:"currencyCode,omitempty"`
+	Debtorinstitutionid                         string `json:"debtorInstitutionId,omitempty"`
+	Debtoraccountid                             string `json:"debtorAccountId,omitempty"`
+	Debtoraccounttype                           string `json:"debtorAccountType,omitempty"`
+	Creditorinstitutionid                       string `json:"creditorInstitutionId,omitempty"`
+	Creditoraccountid                           string `json:"creditorAccountId,omitempty"`
+	Creditoraccounttype                         string `json:"creditorAccountType,omitempty"`
+	Remittanceinformationunstructured           string `json:"remittanceInformationUnstructured,omitempty"`
+	Underlyingdebtorname                        string `json:"underlyingDebtorName,omitempty"`
+	Underlyingdebtoraccountid                   string `json:"underlyingDebtorAccountId,omitempty"`
+	Underlyingdebtorbankid                      string `json:"underlyingDebtorBankId,omitempty"`
+	Underlyingcreditorname                      string `json:"underlyingCreditorName,omitempty"`
+	Underlyingcreditoraccountid                 string `json:"underlyingCreditorAccountId,omitempty"`
+	Underlyingcreditorbankid                    string `json:"underlyingCreditorBankId,omitempty"`
+	Underlyingremittanceinformationunstructured string `json:"underlyingRemittanceInformationUnstructured,omitempty"`
+}
+
+// PACS008CTReturn returns a credit transfer we received, or part of it, to
+// its debtor. Parties, accounts and agents are taken from the original.
+type PACS008CTReturn struct {
+	Messageid                         string `json:"messageId,omitempty"`
+	Creationdatetime                  string `json:"creationDateTime,omitempty"`
+	Settlementmethod                  string `json:"settlementMethod,omitempty"`
+	Endtoendid                        string `json:"endToEndId,omitempty"`
+	Transactionid                     string `json:"transactionId,omitempty"`
+	Paymentchannelid                  string `json:"paymentChannelId,omitempty"`
+	Categorypurpose                   string `json:"categoryPurpose,omitempty"`
+	Originalendtoendid                string `json:"originalEndToEndId,omitempty"`
+	Interbanksettlementamount         string `json:"InterBankSettlementAmount,omitempty"`
+	Currencycode                      string `json:"currencyCode,omitempty"`
+	Chargebearer                      string `json:"chargeBearer,omitempty"`
+	Remittanceinformationunstructured string `json:"remittanceInformationUnstructured,omitempty"`
+}
+
+// ChannelErrorResponse is what the channel gets back for a request the
+// gateway could not turn into a message or could not get answered
+type ChannelErrorResponse struct {
+	Msgtype    string `json:"msgType,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Reasoncode string `json:"reasonCode,omitempty"`
+	Reasontext string `json:"reasonText,omitempty"`
+	Errortext  string `json:"errorText,omitempty"`
+}
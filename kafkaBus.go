@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+// kafkaBus is the MessageBus on top of confluent-kafka-go. It owns the one
+// Producer (Kafka) of the process, every channel connection publishes
+// through it and its delivery reports go back to the publisher.
+type kafkaBus struct {
+	cfg KafkaConfig
+	p   *kafka.Producer
+
+	mu        sync.Mutex
+	consumers []*kafka.Consumer
+	closing   chan struct{}
+	wg        sync.WaitGroup
+}
+
+// deliveryTimeout should be shorter than the response timeout, so the channel
+// learns about an undeliverable request before it gives up waiting
+func newKafkaBus(cfg KafkaConfig, deliveryTimeout time.Duration) (*kafkaBus, error) {
+	conf := kafka.ConfigMap{
+		"message.timeout.ms": int(deliveryTimeout / time.Millisecond),
+	}
+	for k, v := range cfg.configMap(cfg.Producer) {
+		conf[k] = v
+	}
+	conf["bootstrap.servers"] = cfg.Brokers
+
+	p, err := kafka.NewProducer(&conf)
+	if err != nil {
+		return nil, fmt.Errorf("create producer: %v", err)
+	}
+
+	b := &kafkaBus{
+		cfg:     cfg,
+		p:       p,
+		closing: make(chan struct{}),
+	}
+	go b.deliveryReports()
+
+	fmt.Println("Producer started!")
+	return b, nil
+}
+
+func (b *kafkaBus) Publish(msg busMessage, delivered func(error)) error {
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+
+	topic := msg.Topic
+	return b.p.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Value:          msg.Value,
+		Headers:        headers,
+		Opaque:         delivered,
+	}, nil)
+}
+
+func (b *kafkaBus) deliveryReports() {
+	for e := range b.p.Events() {
+		switch ev := e.(type) {
+		case *kafka.Message:
+			if ev.TopicPartition.Error != nil {
+				log.Printf("Delivery to %s failed: %v\n", ev.TopicPartition, ev.TopicPartition.Error)
+			}
+			if delivered, ok := ev.Opaque.(func(error)); ok && delivered != nil {
+				delivered(ev.TopicPartition.Error)
+			}
+
+		case kafka.Error:
+			log.Printf("Producer error: %v\n", ev)
+		}
+	}
+}
+
+func (b *kafkaBus) Subscribe(topic string, handler func(busMessage)) error {
+	// Setting up Consumer (Kafka) config
+	conf := kafka.ConfigMap{
+		"auto.offset.reset": "latest",
+	}
+	for k, v := range b.cfg.configMap(b.cfg.Consumer) {
+		conf[k] = v
+	}
+	conf["bootstrap.servers"] = b.cfg.Brokers
+	conf["group.id"] = b.cfg.GroupId
+
+	c, err := kafka.NewConsumer(&conf)
+	if err != nil {
+		return fmt.Errorf("create consumer: %v", err)
+	}
+
+	if err := c.SubscribeTopics([]string{topic}, nil); err != nil {
+		c.Close()
+		return err
+	}
+
+	b.mu.Lock()
+	b.consumers = append(b.consumers, c)
+	b.mu.Unlock()
+
+	b.wg.Add(1)
+	go b.consume(c, handler)
+	return nil
+}
+
+func (b *kafkaBus) consume(c *kafka.Consumer, handler func(busMessage)) {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-b.closing:
+			return
+		default:
+		}
+
+		msg, err := c.ReadMessage(100 * time.Millisecond)
+		if err != nil {
+			if kerr, ok := err.(kafka.Error); ok && kerr.Code() == kafka.ErrTimedOut {
+				continue
+			}
+			log.Printf("Consumer error: %v (%v)\n", err, msg)
+			continue
+		}
+
+		log.Printf("Message consumed on %s: %s\n", msg.TopicPartition, string(msg.Value))
+
+		headers := make(map[string]string, len(msg.Headers))
+		for _, h := range msg.Headers {
+			headers[h.Key] = string(h.Value)
+		}
+		handler(busMessage{Topic: *msg.TopicPartition.Topic, Value: msg.Value, Headers: headers})
+	}
+}
+
+func (b *kafkaBus) Close() {
+	close(b.closing)
+	b.wg.Wait()
+
+	b.mu.Lock()
+	for _, c := range b.consumers {
+		c.Close()
+	}
+	b.mu.Unlock()
+
+	b.p.Flush(3 * 1000)
+	b.p.Close()
+	log.Println("Producer closing!")
+}
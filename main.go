@@ -2,15 +2,15 @@ package main
 
 import (
 	"bufio"
-	"bytes"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 	"os"
-	"strconv"
+	"sync"
 	"time"
-
-	"github.com/confluentinc/confluent-kafka-go/kafka"
 )
 
 // TODO : Tambahkan AppHdr ke mapping
@@ -19,197 +19,260 @@ import (
 // TODO : Service baru untuk proses ISO8583
 
 var (
-	connType        = "tcp"
-	listenIP        = "0.0.0.0"
-	listenPORT      = "3380"
-	tempStorage     []resConsume            // array for save consume result
-	channelArrChan  = make(chan resConsume) // channel for
-	producerArrChan = make(chan resConsume) // channel for receive data from `Consumer (Kafka)` and send data to channelChan
+	config       Config
+	idGenerator  *correlationIdGenerator // correlation ids, prefixed with `config.NodeId`
+	registry     *pendingRegistry        // requests waiting for response, keyed by `resConsume.Head`
+	bus          MessageBus              // shared by all channel connections
+	headers      *appHdrBuilder          // BI-FAST identifiers the channel leaves out
+	signatures   *signatureService       // XMLDSig of outgoing and incoming messages
+	transactions *transactionStore       // received credit transfers and their returns
+	settlements  sync.WaitGroup          // inbound credit transfers waiting for core banking
 )
 
+// correlationHeader is the Kafka header carrying `resConsume.Head` to the
+// adapter and back
+const correlationHeader = "uniqueKey"
+
 type resConsume struct {
 	Head    string `json:"stan"`
 	Content string `json:"msgin"`
 }
 
 func main() {
-	go kafkaConsumer()
-	l, err := net.Listen(connType, listenIP+":"+listenPORT)
+	var err error
+	config, err = loadConfig(os.Args[1:], os.Environ())
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
 	if err != nil {
-		fmt.Println("Error listening:", err.Error())
-		os.Exit(1)
+		log.Fatalln(err)
 	}
-	defer l.Close()
-	fmt.Println("Listening on " + listenIP + ":" + listenPORT)
 
-	for {
-		conn, err := l.Accept()
+	// checked by loadConfig
+	biFastZone, _ = time.LoadLocation(config.TimeZone)
 
-		if err != nil {
-			fmt.Println("Error accepting: ", err.Error())
-			os.Exit(1)
-		}
-		fmt.Println("new connection detected!")
-		go testReceive(conn)
-		go kafkaProducer()
+	idGenerator, err = newCorrelationIdGenerator(config.NodeId, time.Now())
+	if err != nil {
+		log.Fatalln("Error starting:", err)
 	}
-}
+	registry = newPendingRegistry(config.MaxPending)
 
-func testReceive(conn net.Conn) {
-	for {
-		reader := bufio.NewReader(conn)
+	seq, err := openDailySequence(config.SequenceFile, config.SequencePartition)
+	if err != nil {
+		log.Fatalln("Error starting:", err)
+	}
+	headers = newAppHdrBuilder(seq, config.ChannelType)
 
-		message := make([]byte, 1024)
-		_, readErr := reader.Read(message)
-		message = bytes.Trim(message, "\x00")
-		if readErr != nil {
-			fmt.Println("failed:", readErr)
-			return
-		}
+	signatures, err = newSignatureService(config.Signature)
+	if err != nil {
+		log.Fatalln("Error starting:", err)
+	}
 
-		head := strconv.Itoa(makeTimestamp())
+	transactions, err = openTransactionStore(config.TransactionFile)
+	if err != nil {
+		log.Fatalln("Error starting:", err)
+	}
+	defer transactions.Close()
 
-		data := resConsume{
-			Head:    head,
-			Content: string(message),
-		}
+	bus, err = newMessageBus(config)
+	if err != nil {
+		log.Fatalln("Error starting message bus:", err)
+	}
+	defer bus.Close()
 
-		channelArrChan <- data
-		go testSend(conn, head)
+	if err := bus.Subscribe(config.Kafka.ResponseTopic, handleResponse); err != nil {
+		log.Fatalln("Error subscribing:", err)
 	}
-}
 
-func makeTimestamp() int {
-	return int(time.Now().UnixNano()) / int(time.Millisecond)
-}
+	for i, ln := range config.Listeners {
+		// framing is already checked by loadConfig
+		framer, _ := newFramer(ln.Framing)
 
-func testSend(conn net.Conn, head string) {
-	msgConsume := getValueFromArray(head)
-	var response string
-	if msgConsume.Head == head {
-		response = msgConsume.Content
-	} else {
-		response = "fail to get response"
-	}
-	// send to server
-	fmt.Fprintf(conn, response+"\n")
-	// wait for reply
-	message, _ := bufio.NewReader(conn).ReadString('\n')
+		l, err := net.Listen(ln.Network, ln.Address)
+		if err != nil {
+			log.Fatalln("Error listening:", err)
+		}
+		defer l.Close()
+		fmt.Println("Listening on " + ln.Address + " (" + ln.Framing + " framing)")
 
-	fmt.Println(message)
+		if i == len(config.Listeners)-1 {
+			serve(l, framer)
+		} else {
+			go serve(l, framer)
+		}
+	}
 }
 
-func produceMsgToKafka(broker string, topic string, message <-chan resConsume) {
-	//put channel data in variable
-	data := <-message
-	fmt.Println("Producer started!")
+func serve(l net.Listener, framer Framer) {
+	for {
+		conn, err := l.Accept()
 
-	// Setting up Consumer (Kafka) config
-	p, err := kafka.NewProducer(&kafka.ConfigMap{
-		"bootstrap.servers": broker,
-	})
-	if err != nil {
-		panic(err)
+		if err != nil {
+			fmt.Println("Error accepting: ", err.Error())
+			os.Exit(1)
+		}
+		fmt.Println("new connection detected!")
+		go testReceive(&channelConn{Conn: conn, framer: framer})
 	}
-	defer p.Close()
+}
 
-	//set msg header
-	header := map[string]string{
-		"key":   "uniqueKey",
-		"value": data.Head,
-	}
+// channelConn is a connection from a channel app. Requests and responses on
+// it use the framing of the listener it was accepted on.
+type channelConn struct {
+	net.Conn
+	framer Framer
+	mu     sync.Mutex // responses of concurrent requests must not interleave
+}
 
-	//produce msg
-	p.Produce(&kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
-		Value:          []byte(data.Content),
-		Headers:        []kafka.Header{{Key: header["key"], Value: []byte(header["value"])}},
-	}, nil)
+func (c *channelConn) reply(msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.framer.WriteFrame(c.Conn, msg)
+}
 
-	p.Flush(3 * 1000)
+// testReceive serves the requests of conn until it drops. It returns once
+// every request of conn is answered or released.
+func testReceive(conn *channelConn) {
+	defer conn.Close()
 
-	log.Println("Producer closing!")
+	var sends sync.WaitGroup
+	defer sends.Wait()
 
-	// Done with worker
-}
+	// cancelled when the connection drops, releasing every request still waiting on it
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-func kafkaProducer() {
+	reader := bufio.NewReader(conn)
 	for {
-		select {
-		case newRequest := <-channelArrChan:
-			log.Println("New request from `Channel` is ready to produce to Kafka")
+		message, readErr := conn.framer.ReadFrame(reader)
+		if readErr != nil {
+			fmt.Println("failed:", readErr)
+			return
+		}
 
-			// Run Producer (Kafka)
-			go produceMsgToKafka("localhost:9092", "mpc.json.bifast.request", producerArrChan)
+		cid := idGenerator.next()
+		head := cid.id
+		log.Printf("New request %s (stan %s)\n", head, cid.stan)
 
-			// Send new request to producerChan, then produce the new request to Kafka
-			producerArrChan <- newRequest
+		kind, msg, value, err := encodeChannelRequest(message)
+		if err != nil {
+			log.Printf("Request %s rejected: %v\n", head, err)
+			conn.reply(requestErrorResponse(err))
+			continue
+		}
 
-		// keep looping if there is none new request
-		default:
+		// register before producing, the response may come back before testSend runs
+		pending, err := registry.register(head)
+		if err != nil {
+			log.Printf("Request %s rejected: %v\n", head, err)
+			releaseReturn(kind, msg)
+			conn.reply(channelErrorResponse(kind, "RJCT", reasonNotSent, err))
 			continue
 		}
-	}
-}
 
-func kafkaConsumer() {
+		data := busMessage{
+			Topic:   config.Kafka.requestTopic(kind),
+			Value:   value,
+			Headers: map[string]string{correlationHeader: head, msgTypeHeader: string(kind)},
+		}
 
-	// Setting up Consumer (Kafka) config
-	c, err := kafka.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers": "localhost:9092",
-		"group.id":          "test",
-		"auto.offset.reset": "latest",
-	})
+		if err := bus.Publish(data, deliveryReport(head)); err != nil {
+			log.Printf("Fail to produce %s: %v\n", head, err)
+			pending.cancel()
+			releaseReturn(kind, msg)
+			conn.reply(channelErrorResponse(kind, "RJCT", reasonNotSent, err))
+			continue
+		}
+		log.Printf("New %s from `Channel` is produced to %s\n", kind, data.Topic)
 
-	if err != nil {
-		log.Println(err.Error())
+		sends.Add(1)
+		go func() {
+			defer sends.Done()
+			testSend(ctx, conn, pending, kind, msg)
+		}()
 	}
+}
 
-	// Subscribe to topics
-	c.SubscribeTopics([]string{"mpc.json.bifast.response"}, nil)
-
-	for {
-		msg, err := c.ReadMessage(-1)
-		if err == nil {
-			log.Println("New Request from Kafka")
-			log.Printf("Message consumed on %s: %s\n", msg.TopicPartition, string(msg.Value))
-			fmt.Printf("Message on %s: %s\n", msg.TopicPartition, string(msg.Value))
-
-			// Send any consumed event to consumerChan
-			// go getResponseUpdated(consumerChan)
-
-			msgResult := resConsume{
-				Head:    string(msg.Headers[0].Value),
-				Content: string(msg.Value),
-			}
-			tempStorage = append(tempStorage, msgResult)
-		} else {
-			log.Printf("Consumer error: %v (%v)\n", err, msg)
+// deliveryReport ends the wait of head right away when its request could not
+// be delivered, instead of letting it run into the response timeout
+func deliveryReport(head string) func(error) {
+	return func(err error) {
+		if err != nil {
+			registry.fail(head, fmt.Errorf("%w: %v", errDeliveryFailed, err))
 		}
 	}
 }
 
-func getValueFromArray(head string) resConsume {
-	search := true
-	var res resConsume
-	limit := 0
-	for search {
-		index := 0
-		fmt.Println(len(tempStorage))
-		for _, ele := range tempStorage {
-			if ele.Head == head {
-				search = false
-				res = ele
-				tempStorage = append(tempStorage[:index], tempStorage[index+1:]...)
-			}
-			index++
-		}
-		time.Sleep(100 * time.Millisecond)
-		limit++
-		if limit == 500 {
+// testSend answers the channel with the response to msg. A credit transfer
+// without response in time gets its status inquired, and is reported as
+// pending rather than failed when that stays unanswered too. Whatever else
+// fails, the channel gets a ChannelErrorResponse.
+func testSend(connCtx context.Context, conn *channelConn, pending *pendingRequest, kind requestKind, msg BusMsg) {
+	ctx, cancel := context.WithTimeout(connCtx, time.Duration(config.ResponseTimeout))
+	defer cancel()
+
+	msgConsume, err := pending.wait(ctx)
+	inquired := false
+	if err == errResponseTimeout && kind != kindAccountEnquiry && len(config.StatusInquiry) > 0 {
+		log.Printf("Request %s timed out, inquiring its status\n", pending.head)
+		msgConsume, err = inquireStatus(connCtx, pending.head, msg)
+		inquired = true
+	}
+	if connCtx.Err() != nil {
+		// connection already dropped, nobody to answer
+		return
+	}
+
+	var response []byte
+	switch {
+	case err == nil:
+		res, err := channelResponse(kind, []byte(msgConsume.Content))
+		if err != nil {
+			log.Printf("Request %s got invalid response: %v\n", pending.head, err)
+			response = channelErrorResponse(kind, unsettledStatus(kind), reasonInvalidFormat, err)
 			break
 		}
+		response = res
+	case err == errResponseTimeout:
+		log.Printf("Request %s failed: %v\n", pending.head, err)
+		response = channelErrorResponse(kind, unsettledStatus(kind), reasonNoResponse, err)
+		if inquired {
+			if res, err := pendingStatusResponse(msg); err == nil {
+				response = res
+			}
+		}
+	case !inquired && errors.Is(err, errDeliveryFailed):
+		log.Printf("Request %s failed: %v\n", pending.head, err)
+		releaseReturn(kind, msg)
+		response = channelErrorResponse(kind, "RJCT", reasonNotSent, err)
+	default:
+		// the request went out, its status inquiry failed
+		log.Printf("Request %s failed: %v\n", pending.head, err)
+		response = channelErrorResponse(kind, unsettledStatus(kind), reasonNoResponse, err)
+	}
+
+	// send to channel with the framing the request came in
+	if err := conn.reply(response); err != nil {
+		log.Printf("Fail to send response %s: %v\n", pending.head, err)
+	}
+}
+
+// handleResponse hands a message consumed from the response topic to the
+// request waiting for it, a channel request or a credit transfer waiting for
+// the decision of core banking. What no request waits for may be a credit
+// transfer to us, see answerCreditTransfer.
+func handleResponse(msg busMessage) {
+	head, ok := msg.Headers[correlationHeader]
+	if ok && registry.deliver(resConsume{Head: head, Content: string(msg.Value)}) {
+		return
+	}
+	if answerCreditTransfer(msg.Value) {
+		return
+	}
 
+	if !ok {
+		log.Println("Message without correlation header, dropped")
+		return
 	}
-	return res
+	log.Printf("No request waiting for %s (%d pending), response dropped\n", head, registry.size())
 }
@@ -0,0 +1,389 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatewayOption changes the gateway of startGateway before it starts
+type gatewayOption func()
+
+func withResponseTimeout(d time.Duration) gatewayOption {
+	return func() { config.ResponseTimeout = duration(d) }
+}
+
+// startGateway runs the gateway on a memory bus with respond playing the
+// adapter and returns the address of its json framed listener
+func startGateway(t *testing.T, respond responder, opts ...gatewayOption) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	config = defaultConfig()
+	config.Bus = "memory"
+	config.ResponseTimeout = duration(2 * time.Second)
+	config.StatusInquiry = nil
+	config.SequenceFile = filepath.Join(dir, "netChannel.seq")
+	config.TransactionFile = filepath.Join(dir, "netChannel.tx")
+
+	var err error
+	if idGenerator, err = newCorrelationIdGenerator("TEST", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	registry = newPendingRegistry(config.MaxPending)
+	seq, err := openDailySequence(config.SequenceFile, config.SequencePartition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers = newAppHdrBuilder(seq, config.ChannelType)
+	if signatures, err = newSignatureService(SignatureConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if transactions, err = openTransactionStore(config.TransactionFile); err != nil {
+		t.Fatal(err)
+	}
+
+	b := newMemoryBus()
+	for _, topic := range config.Kafka.requestTopics() {
+		b.respond(topic, respond)
+	}
+	if err := b.Subscribe(config.Kafka.ResponseTopic, handleResponse); err != nil {
+		t.Fatal(err)
+	}
+	bus = b
+	for _, opt := range opts {
+		opt()
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	framer, _ := newFramer("json")
+	var (
+		mu       sync.Mutex
+		conns    []net.Conn
+		serving  sync.WaitGroup
+		accepted = make(chan struct{})
+	)
+	go func() {
+		defer close(accepted)
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+			serving.Add(1)
+			go func() {
+				defer serving.Done()
+				testReceive(&channelConn{Conn: conn, framer: framer})
+			}()
+		}
+	}()
+
+	// the next test sets the globals anew, nothing of this gateway may be
+	// running by then
+	t.Cleanup(func() {
+		l.Close()
+		<-accepted
+		mu.Lock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+		mu.Unlock()
+		serving.Wait()
+		b.Close()
+		settlements.Wait()
+		transactions.Close()
+	})
+	return l.Addr().String()
+}
+
+// settleAll is an adapter behind which the creditor settles every credit
+// transfer and confirms every account enquiry
+func settleAll(req busMessage) []busMessage {
+	msg, err := parseBusMsgJSON(req.Value)
+	if err != nil {
+		return nil
+	}
+	if k := msg.Document.Kind(); k != kindPacs008 && k != kindPacs009 {
+		return nil
+	}
+	status := "ACSC"
+	if isAccountEnquiry(msg) {
+		status = "ACTC"
+	}
+	value, err := encodeStatusReport(msg, status, "")
+	if err != nil {
+		return nil
+	}
+	return []busMessage{{Topic: config.Kafka.ResponseTopic, Value: value, Headers: req.Headers}}
+}
+
+// exchange sends request to the gateway at addr and returns its reply
+func exchange(t *testing.T, addr string, request []byte) []byte {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	if _, err := conn.Write(request); err != nil {
+		t.Fatal(err)
+	}
+	reply, err := jsonStreamFramer{}.ReadFrame(bufio.NewReader(conn))
+	if err != nil {
+		t.Fatalf("read reply: %v", err)
+	}
+	return reply
+}
+
+func readSample(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("samples", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestEndToEndCreditTransfer(t *testing.T) {
+	addr := startGateway(t, settleAll)
+
+	var res PACS002StatusResponse
+	reply := exchange(t, addr, readSample(t, "PACS008CreditTransfer"))
+	if err := json.Unmarshal(reply, &res); err != nil {
+		t.Fatalf("reply %s: %v", reply, err)
+	}
+	if res.Status != "ACSC" || res.Endtoendid != "20210301INDOIDJA010ORB12345678" {
+		t.Errorf("reply = %s, want ACSC for the sample EndToEndId", reply)
+	}
+}
+
+func TestEndToEndAccountEnquiry(t *testing.T) {
+	addr := startGateway(t, settleAll)
+
+	var res PACS008AccEnqResponse
+	reply := exchange(t, addr, readSample(t, "PACS008AccEnq"))
+	if err := json.Unmarshal(reply, &res); err != nil {
+		t.Fatalf("reply %s: %v", reply, err)
+	}
+	if res.Status != "ACTC" || res.Customeraccountnumber != "987654321" {
+		t.Errorf("reply = %s, want ACTC for account 987654321", reply)
+	}
+}
+
+func TestEndToEndUnrecognizedRequest(t *testing.T) {
+	published := make(chan busMessage, 1)
+	addr := startGateway(t, func(req busMessage) []busMessage {
+		published <- req
+		return nil
+	})
+
+	var res ChannelErrorResponse
+	reply := exchange(t, addr, []byte(`{"hello": "world"}`))
+	if err := json.Unmarshal(reply, &res); err != nil {
+		t.Fatalf("reply %s: %v", reply, err)
+	}
+	if res.Status != "RJCT" || res.Reasoncode != reasonInvalidFormat {
+		t.Errorf("reply = %s, want RJCT %s", reply, reasonInvalidFormat)
+	}
+	select {
+	case req := <-published:
+		t.Errorf("unrecognized request was produced: %s", req.Value)
+	default:
+	}
+}
+
+// a credit transfer to us goes to core banking, whose decision is reported
+// in the pacs.002, the gateway itself rejects only what breaks the format
+func TestInboundCreditTransfer(t *testing.T) {
+	tests := []struct {
+		name     string
+		breakMsg func(*BusMsg)
+		decision string // of core banking, "" answers nothing
+		status   string // of the pacs.002, "" expects none
+		reason   string
+		kept     bool
+	}{
+		{"settled", nil, `{"status":"ACSC"}`, "ACSC", "", true},
+		{"no account", nil, `{"status":"RJCT","reasonCode":"AC03"}`, "RJCT", "AC03", false},
+		{"closed account", nil, `{"status":"RJCT","reasonCode":"AC04"}`, "RJCT", "AC04", false},
+		{"invalid format", func(msg *BusMsg) { msg.Document.FIToFICstmrCdtTrf.GrpHdr.NbOfTxs = "x" }, `{"status":"ACSC"}`, "RJCT", reasonInvalidFormat, false},
+		{"no decision", nil, "", "", "", false},
+		{"invalid decision", nil, `{"status":"ACTC"}`, "", "", false},
+		{"reject without reason", nil, `{"status":"RJCT"}`, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reports := make(chan BusMsg, 1)
+			startGateway(t, func(req busMessage) []busMessage {
+				if msg, err := parseBusMsgJSON(req.Value); err == nil && msg.Document.Kind() == kindPacs002 {
+					reports <- msg
+				}
+				return nil
+			}, withResponseTimeout(300*time.Millisecond))
+
+			forwarded := make(chan busMessage, 1)
+			bus.(*memoryBus).respond(config.Kafka.CoreBankingTopic, func(req busMessage) []busMessage {
+				forwarded <- req
+				if tt.decision == "" {
+					return nil
+				}
+				return []busMessage{{Topic: config.Kafka.ResponseTopic, Value: []byte(tt.decision), Headers: req.Headers}}
+			})
+
+			msg, err := mapCreditTransfer(creditTransferSample(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := headers.complete(&msg, creditTransferTxType); err != nil {
+				t.Fatal(err)
+			}
+			if tt.breakMsg != nil {
+				tt.breakMsg(&msg)
+			}
+			value, err := json.Marshal(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := bus.Publish(busMessage{Topic: config.Kafka.ResponseTopic, Value: value}, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.breakMsg == nil {
+				select {
+				case req := <-forwarded:
+					if req.Headers[msgTypeHeader] != inboundCreditTransferMsgType {
+						t.Errorf("forwarded with headers %v", req.Headers)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatal("credit transfer not forwarded to core banking")
+				}
+			}
+
+			var report *BusMsg
+			select {
+			case rpt := <-reports:
+				report = &rpt
+			case <-time.After(time.Duration(config.ResponseTimeout) + time.Second):
+			}
+			switch {
+			case tt.status == "" && report != nil:
+				t.Fatalf("status report %s without decision of core banking", report.Document.FIToFIPmtStsRpt.TxInfAndSts[0].TxSts)
+			case tt.status == "":
+			case report == nil:
+				t.Fatalf("no status report, want %s", tt.status)
+			default:
+				sts := report.Document.FIToFIPmtStsRpt.TxInfAndSts[0]
+				var reason string
+				if len(sts.StsRsnInf) > 0 {
+					reason = string(sts.StsRsnInf[0].Rsn.Cd)
+				}
+				if string(sts.TxSts) != tt.status || reason != tt.reason {
+					t.Errorf("status report %s %s, want %s %s", sts.TxSts, reason, tt.status, tt.reason)
+				}
+			}
+
+			if tt.breakMsg != nil {
+				select {
+				case <-forwarded:
+					t.Error("credit transfer of invalid format forwarded to core banking")
+				default:
+				}
+			}
+			endToEndId := string(msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf[0].PmtId.EndToEndId)
+			if _, _, err := transactions.original(endToEndId); (err == nil) != tt.kept {
+				t.Errorf("credit transfer kept = %v, want %v", err == nil, tt.kept)
+			}
+		})
+	}
+}
+
+// failingDelivery is a bus on which nothing published arrives
+type failingDelivery struct {
+	*memoryBus
+}
+
+func (b failingDelivery) Publish(msg busMessage, delivered func(error)) error {
+	return b.memoryBus.Publish(msg, func(error) {
+		if delivered != nil {
+			delivered(errors.New("broker down"))
+		}
+	})
+}
+
+// withFullRegistry leaves no room for another request
+func withFullRegistry() {
+	registry = newPendingRegistry(1)
+	registry.register("held")
+}
+
+// withFailingDelivery makes every publish fail after it was queued
+func withFailingDelivery() {
+	bus = failingDelivery{bus.(*memoryBus)}
+}
+
+// withClosedBus makes every publish fail right away
+func withClosedBus() {
+	bus.Close()
+}
+
+// every request the gateway cannot get answered is replied to with status
+// and reason
+func TestEndToEndFailureReplies(t *testing.T) {
+	unanswered := func(req busMessage) []busMessage { return nil }
+	garbled := func(req busMessage) []busMessage {
+		return []busMessage{{Topic: config.Kafka.ResponseTopic, Value: []byte(`{"BusMsg": 1}`), Headers: req.Headers}}
+	}
+	tests := []struct {
+		name     string
+		sample   string
+		respond  responder
+		breakBus gatewayOption
+		status   string
+		reason   string
+	}{
+		{"registry full", "PACS008CreditTransfer", settleAll, withFullRegistry, "RJCT", reasonNotSent},
+		{"publish fails", "PACS008CreditTransfer", settleAll, withClosedBus, "RJCT", reasonNotSent},
+		{"delivery fails", "PACS008CreditTransfer", settleAll, withFailingDelivery, "RJCT", reasonNotSent},
+		{"credit transfer timeout", "PACS008CreditTransfer", unanswered, nil, "PDNG", reasonNoResponse},
+		{"account enquiry timeout", "PACS008AccEnq", unanswered, nil, "RJCT", reasonNoResponse},
+		{"invalid response", "PACS008CreditTransfer", garbled, nil, "PDNG", reasonInvalidFormat},
+		{"account enquiry invalid response", "PACS008AccEnq", garbled, nil, "RJCT", reasonInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []gatewayOption{withResponseTimeout(300 * time.Millisecond)}
+			if tt.breakBus != nil {
+				opts = append(opts, tt.breakBus)
+			}
+			addr := startGateway(t, tt.respond, opts...)
+
+			var res ChannelErrorResponse
+			reply := exchange(t, addr, readSample(t, tt.sample))
+			if err := json.Unmarshal(reply, &res); err != nil {
+				t.Fatalf("reply %s: %v", reply, err)
+			}
+			if res.Msgtype != tt.sample || res.Status != tt.status || res.Reasoncode != tt.reason {
+				t.Errorf("reply = %s, want %s %s %s", reply, tt.sample, tt.status, tt.reason)
+			}
+			if res.Reasontext == "" || res.Errortext == "" {
+				t.Errorf("reply = %s, want reasonText and errorText", reply)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"sync"
+)
+
+var errBusClosed = errors.New("message bus is closed")
+
+// memoryBus is a MessageBus without broker. Messages are handed to the
+// subscribers of their topic in the background, and responders registered
+// with respond play the *ISO20022 Adapter* by answering what is published.
+type memoryBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]func(busMessage)
+	wg       sync.WaitGroup
+	closed   bool
+}
+
+// responder answers a published request, returning no message simulates an
+// adapter that never responds
+type responder func(req busMessage) []busMessage
+
+func newMemoryBus() *memoryBus {
+	return &memoryBus{handlers: make(map[string][]func(busMessage))}
+}
+
+func (b *memoryBus) Publish(msg busMessage, delivered func(error)) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return errBusClosed
+	}
+
+	msg = copyBusMessage(msg)
+	handlers := b.handlers[msg.Topic]
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		if delivered != nil {
+			delivered(nil)
+		}
+		for _, h := range handlers {
+			h(copyBusMessage(msg))
+		}
+	}()
+	return nil
+}
+
+func (b *memoryBus) Subscribe(topic string, handler func(busMessage)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return errBusClosed
+	}
+	b.handlers[topic] = append(b.handlers[topic], handler)
+	return nil
+}
+
+// respond publishes whatever r returns for every message on topic
+func (b *memoryBus) respond(topic string, r responder) {
+	b.Subscribe(topic, func(req busMessage) {
+		for _, res := range r(req) {
+			b.Publish(res, nil)
+		}
+	})
+}
+
+func (b *memoryBus) Close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+func copyBusMessage(msg busMessage) busMessage {
+	headers := make(map[string]string, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	return busMessage{
+		Topic:   msg.Topic,
+		Value:   append([]byte(nil), msg.Value...),
+		Headers: headers,
+	}
+}
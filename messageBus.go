@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// busMessage is a message on the bus, independent of the transport carrying it
+type busMessage struct {
+	Topic   string
+	Value   []byte
+	Headers map[string]string
+}
+
+// MessageBus carries requests to the *ISO20022 Adapter* and its responses
+// back. kafkaBus is the production implementation, memoryBus carries messages
+// within the process and lets tests play the adapter.
+type MessageBus interface {
+	// Publish queues msg. The error only covers queueing, delivered (may be
+	// nil) is called once the transport knows whether msg arrived.
+	Publish(msg busMessage, delivered func(error)) error
+
+	// Subscribe calls handler for every message arriving on topic
+	Subscribe(topic string, handler func(busMessage)) error
+
+	// Close delivers what is still queued and stops all subscriptions
+	Close()
+}
+
+func newMessageBus(cfg Config) (MessageBus, error) {
+	switch cfg.Bus {
+	case "kafka":
+		return newKafkaBus(cfg.Kafka, time.Duration(cfg.ResponseTimeout)/2)
+	}
+	return nil, fmt.Errorf("unknown message bus %q", cfg.Bus)
+}
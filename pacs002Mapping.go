@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// transaction statuses BI-FAST reports in TxSts
+var transactionStatuses = map[string]string{
+	"ACSC": "accepted, settlement completed",
+	"ACTC": "accepted, technical validation",
+	"ACSP": "accepted, settlement in process",
+	"ACCP": "accepted, customer profile",
+	"PDNG": "pending",
+	"RJCT": "rejected",
+}
+
+// statusReasons explains the reason codes used when AddtlInf gives no text
+var statusReasons = map[string]string{
+	"AB05": "timeout at creditor agent",
+	"AB06": "timeout at instructed agent",
+	"AB07": "agent is not online",
+	"AC01": "incorrect account number",
+	"AC03": "invalid creditor account number",
+	"AC04": "closed account number",
+	"AC06": "blocked account",
+	"AG01": "transaction forbidden",
+	"AG03": "transaction not supported",
+	"AM02": "not allowed amount",
+	"AM04": "insufficient funds",
+	"AM18": "invalid number of transactions",
+	"BE01": "inconsistent with end customer",
+	"DS01": "electronic signature is not correct",
+	"DS0A": "message is not signed",
+	"DUPL": "duplicate payment",
+	"FF01": "invalid file format",
+	"NARR": "narrative",
+	"RR04": "regulatory reason",
+}
+
+// mapStatusReport turns the pacs.002 answering a credit transfer into the
+// flat PACS002StatusResponse of the channel. A report the channel could not
+// act on fails instead of producing empty fields.
+func mapStatusReport(msg BusMsg) (PACS002StatusResponse, error) {
+	if k := msg.Document.Kind(); k != kindPacs002 {
+		return PACS002StatusResponse{}, fmt.Errorf("status report is %s, expected %s", k, kindPacs002)
+	}
+	rpt := msg.Document.FIToFIPmtStsRpt
+
+	txs := rpt.TxInfAndSts
+	if len(txs) == 0 {
+		return PACS002StatusResponse{}, errors.New("status report without TxInfAndSts")
+	}
+	if len(txs) > 1 {
+		return PACS002StatusResponse{}, fmt.Errorf("status report with %d TxInfAndSts, expected 1", len(txs))
+	}
+	tx := txs[0]
+
+	if tx.OrgnlEndToEndId == "" {
+		return PACS002StatusResponse{}, errors.New("status report without OrgnlEndToEndId")
+	}
+	if tx.TxSts == "" {
+		return PACS002StatusResponse{}, errors.New("status report without TxSts")
+	}
+	if _, ok := transactionStatuses[string(tx.TxSts)]; !ok {
+		return PACS002StatusResponse{}, fmt.Errorf("status report with unknown TxSts %q", tx.TxSts)
+	}
+
+	res := PACS002StatusResponse{
+		Endtoendid:               string(tx.OrgnlEndToEndId),
+		Transactionid:            string(tx.OrgnlTxId),
+		Status:                   string(tx.TxSts),
+		Accountservicerreference: string(tx.AcctSvcrRef),
+	}
+	if tx.OrgnlGrpInf != nil {
+		res.Originalmessageid = string(tx.OrgnlGrpInf.OrgnlMsgId)
+	}
+
+	if len(tx.StsRsnInf) > 0 {
+		code, text, err := statusReason(tx.StsRsnInf[0])
+		if err != nil {
+			return PACS002StatusResponse{}, err
+		}
+		res.Reasoncode, res.Reasontext = code, text
+	} else if tx.TxSts == "RJCT" {
+		return PACS002StatusResponse{}, errors.New("status report rejects without StsRsnInf")
+	}
+
+	if len(tx.SplmtryData) > 0 && tx.SplmtryData[0].Envlp.Cdtr != nil {
+		cdtr := tx.SplmtryData[0].Envlp.Cdtr
+		res.Creditortype = string(cdtr.Tp)
+		res.Creditorresidentstatus = string(cdtr.RsdntSts)
+		res.Creditortownname = string(cdtr.TwnNm)
+	}
+	return res, nil
+}
+
+// statusReason reads the code of a StsRsnInf and a text explaining it
+func statusReason(inf StatusReasonInformation12) (string, string, error) {
+	var code string
+	if inf.Rsn != nil {
+		code = string(inf.Rsn.Cd)
+		if code == "" {
+			code = string(inf.Rsn.Prtry)
+		}
+	}
+	if code == "" {
+		return "", "", errors.New("status report with StsRsnInf without Rsn")
+	}
+
+	var lines []string
+	for _, l := range inf.AddtlInf {
+		lines = append(lines, string(l))
+	}
+	text := strings.Join(lines, " ")
+	if text == "" {
+		text = statusReasons[code]
+	}
+	if text == "" {
+		return "", "", fmt.Errorf("status report with unknown reason code %q and no AddtlInf", code)
+	}
+	return code, text, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMapStatusReport(t *testing.T) {
+	testHeaders(t, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC))
+	ct, err := mapCreditTransfer(creditTransferSample(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsn := func(code string, text ...Max105Text) []StatusReasonInformation12 {
+		return []StatusReasonInformation12{{Rsn: &StatusReason6Choice{Cd: ExternalStatusReason1Code(code)}, AddtlInf: text}}
+	}
+
+	tests := []struct {
+		name    string
+		change  func(*PaymentTransaction110, *BusMsg)
+		wantErr string
+		status  string
+		reason  string
+		text    string
+	}{
+		{"settled", nil, "", "ACSC", "", ""},
+		{"rejected", func(tx *PaymentTransaction110, _ *BusMsg) {
+			tx.TxSts, tx.StsRsnInf = "RJCT", rsn("AC03")
+		}, "", "RJCT", "AC03", statusReasons["AC03"]},
+		{"pending", func(tx *PaymentTransaction110, _ *BusMsg) { tx.TxSts = "PDNG" }, "", "PDNG", "", ""},
+		{"reason text", func(tx *PaymentTransaction110, _ *BusMsg) {
+			tx.TxSts, tx.StsRsnInf = "RJCT", rsn("X001", "account", "dormant")
+		}, "", "RJCT", "X001", "account dormant"},
+		{"not a status report", func(_ *PaymentTransaction110, msg *BusMsg) { *msg = ct }, "status report is pacs.008.001.08", "", "", ""},
+		{"missing TxInfAndSts", func(_ *PaymentTransaction110, msg *BusMsg) {
+			msg.Document.FIToFIPmtStsRpt.TxInfAndSts = nil
+		}, "without TxInfAndSts", "", "", ""},
+		{"two TxInfAndSts", func(_ *PaymentTransaction110, msg *BusMsg) {
+			rpt := msg.Document.FIToFIPmtStsRpt
+			rpt.TxInfAndSts = append(rpt.TxInfAndSts, rpt.TxInfAndSts[0])
+		}, "with 2 TxInfAndSts", "", "", ""},
+		{"original not found", func(tx *PaymentTransaction110, _ *BusMsg) { tx.OrgnlEndToEndId = "" }, "without OrgnlEndToEndId", "", "", ""},
+		{"missing status", func(tx *PaymentTransaction110, _ *BusMsg) { tx.TxSts = "" }, "without TxSts", "", "", ""},
+		{"unknown status", func(tx *PaymentTransaction110, _ *BusMsg) { tx.TxSts = "OKAY" }, `unknown TxSts "OKAY"`, "", "", ""},
+		{"rejected without reason", func(tx *PaymentTransaction110, _ *BusMsg) { tx.TxSts = "RJCT" }, "rejects without StsRsnInf", "", "", ""},
+		{"reason without code", func(tx *PaymentTransaction110, _ *BusMsg) {
+			tx.TxSts, tx.StsRsnInf = "RJCT", []StatusReasonInformation12{{AddtlInf: []Max105Text{"rejected"}}}
+		}, "StsRsnInf without Rsn", "", "", ""},
+		{"unknown reason without text", func(tx *PaymentTransaction110, _ *BusMsg) {
+			tx.TxSts, tx.StsRsnInf = "RJCT", rsn("X001")
+		}, `unknown reason code "X001"`, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := statusReport(ct, "ACSC", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.change != nil {
+				tt.change(&msg.Document.FIToFIPmtStsRpt.TxInfAndSts[0], &msg)
+			}
+
+			res, err := mapStatusReport(msg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Status != tt.status || res.Reasoncode != tt.reason || res.Reasontext != tt.text {
+				t.Errorf("got %+v, want %s %s %q", res, tt.status, tt.reason, tt.text)
+			}
+			if res.Endtoendid != "20210301INDOIDJA010ORB12345678" || res.Originalmessageid == "" {
+				t.Errorf("got %+v, want the EndToEndId and MsgId of the sample", res)
+			}
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	biFastHubBIC = "BIFAIDJA" // BI-FAST is the receiver of every AppHdr sent by a participant
+	biFastBizSvc = "BI"
+
+	// transaction types, the first three digits of CtgyPurp.Prtry and part of
+	// BizMsgIdr/EndToEndId
+	creditTransferTxType = "010"
+
+	// scheme defaults of the mandatory pacs.008 codes the channel may leave out
+	defaultSettlementMethod = "CLRG" // cleared by BI-FAST
+	defaultChargeBearer     = "SLEV" // charges as agreed for the service level
+)
+
+// mapCreditTransfer turns the flat PACS008CreditTransfer of the channel into
+// the ISO 20022 pacs.008 of the *ISO20022 Adapter*. messageId and endToEndId
+// may be left out, appHdrBuilder.complete generates them.
+func mapCreditTransfer(req PACS008CreditTransfer) (BusMsg, error) {
+	if err := requireFields(map[string]string{
+		"creationDateTime":          req.Creationdatetime,
+		"InterBankSettlementAmount": req.Interbanksettlementamount,
+		"currencyCode":              req.Currencycode,
+		"debtorBankId":              req.Debtorbankid,
+		"creditorBankId":            req.Creditorbankid,
+	}); err != nil {
+		return BusMsg{}, err
+	}
+
+	var creDtTm ISODateTime
+	if err := creDtTm.UnmarshalText([]byte(req.Creationdatetime)); err != nil {
+		return BusMsg{}, fmt.Errorf("creationDateTime: %v", err)
+	}
+
+	amount, err := parsePaymentAmount(req.Interbanksettlementamount, req.Currencycode)
+	if err != nil {
+		return BusMsg{}, fmt.Errorf("InterBankSettlementAmount: %v", err)
+	}
+
+	// the flat request is one transaction
+	nbOfTxs, err := numberOfTransactions("numberOfTransaction", req.Numberoftransaction, 1)
+	if err != nil {
+		return BusMsg{}, err
+	}
+
+	tx := CreditTransferTransaction39{
+		PmtId: PaymentIdentification7{
+			EndToEndId: Max35Text(req.Endtoendid),
+			TxId:       Max35Text(req.Transactionid),
+		},
+		PmtTpInf: paymentTypeInformation(req.Paymentchannelid, categoryPurpose(creditTransferTxType, req.Categorypurpose)),
+		IntrBkSttlmAmt: ActiveCurrencyAndAmount{
+			Value: amount,
+			Ccy:   ActiveCurrencyCode(req.Currencycode),
+		},
+		ChrgBr:   chargeBearer(req.Chargebearer),
+		Dbtr:     partyIdentification(req.Debtorname, req.Debtororganizationid, req.Debtorprivateid),
+		DbtrAcct: cashAccount(req.Debtoraccountid, req.Debtoraccounttype),
+		DbtrAgt:  financialInstitution(req.Debtorbankid),
+		CdtrAgt:  financialInstitution(req.Creditorbankid),
+		Cdtr:     partyIdentification(req.Creditorname, req.Creditororganizationid, req.Creditorprivateid),
+		CdtrAcct: cashAccount(req.Creditoraccountid, req.Creditoraccounttype),
+	}
+
+	if req.Remittanceinformationunstructured != "" {
+		tx.RmtInf = &RemittanceInformation16{Ustrd: []Max140Text{Max140Text(req.Remittanceinformationunstructured)}}
+	}
+
+	envlp := BI_SupplementaryDataEnvelope1{
+		Dbtr: additionalCustomerInfo(req.Debtortype, req.Debtorresidentstatus, req.Debtortownname),
+		Cdtr: additionalCustomerInfo(req.Creditortype, req.Creditorresidentstatus, req.Creditortownname),
+	}
+	if envlp.Dbtr != nil || envlp.Cdtr != nil {
+		tx.SplmtryData = []BI_SupplementaryData1{{Envlp: envlp}}
+	}
+
+	msg := BusMsg{
+		AppHdr: appHdr(req.Debtorbankid, req.Messageid, pacs008MsgDefIdr, creDtTm),
+	}
+	msg.Document.FIToFICstmrCdtTrf = &FIToFICustomerCreditTransferV08{
+		GrpHdr: GroupHeader93{
+			MsgId:    Max35Text(req.Messageid),
+			CreDtTm:  creDtTm,
+			NbOfTxs:  nbOfTxs,
+			SttlmInf: SettlementInstruction7{SttlmMtd: settlementMethod(req.Settlementmethod)},
+		},
+		CdtTrfTxInf: []CreditTransferTransaction39{tx},
+	}
+	if err := setControlSum(msg.Document.FIToFICstmrCdtTrf); err != nil {
+		return BusMsg{}, err
+	}
+	return msg, nil
+}
+
+// numberOfTransactions is NbOfTxs of a message with n transactions, given
+// is what the channel sent in field and must be n when sent at all
+func numberOfTransactions(field string, given string, n int) (Max15NumericText, error) {
+	nbOfTxs := strconv.Itoa(n)
+	if given != "" && given != nbOfTxs {
+		return "", fmt.Errorf("%s %s, the request has %s transaction(s)", field, given, nbOfTxs)
+	}
+	return Max15NumericText(nbOfTxs), nil
+}
+
+// setControlSum sets GrpHdr.CtrlSum to the exact sum of the transaction amounts
+func setControlSum(ct *FIToFICustomerCreditTransferV08) error {
+	var amounts []ActiveCurrencyAndAmount
+	for _, tx := range ct.CdtTrfTxInf {
+		amounts = append(amounts, tx.IntrBkSttlmAmt)
+	}
+	sum, err := controlSum(amounts)
+	if err != nil {
+		return err
+	}
+	ct.GrpHdr.CtrlSum = sum
+	return nil
+}
+
+// controlSum is the exact sum of the amounts of CdtTrfTxInf
+func controlSum(amounts []ActiveCurrencyAndAmount) (*Decimal, error) {
+	var sum Decimal
+	for i, amt := range amounts {
+		var err error
+		if sum, err = sum.Add(amt.Value); err != nil {
+			return nil, fmt.Errorf("CtrlSum at CdtTrfTxInf[%d]: %v", i, err)
+		}
+	}
+	return &sum, nil
+}
+
+// appHdr addresses a message from the participant with BIC from to BI-FAST
+func appHdr(from string, bizMsgIdr string, msgDefIdr string, creDt ISODateTime) BusinessApplicationHeaderV01 {
+	return BusinessApplicationHeaderV01{
+		Fr:        biFastParty(from),
+		To:        biFastParty(biFastHubBIC),
+		BizMsgIdr: Max35Text(bizMsgIdr),
+		MsgDefIdr: Max35Text(msgDefIdr),
+		BizSvc:    biFastBizSvc,
+		CreDt:     ISONormalisedDateTime(creDt),
+	}
+}
+
+// biFastParty is a participant or BI-FAST itself in Fr/To of the AppHdr
+func biFastParty(bic string) Party9Choice {
+	return Party9Choice{FIId: &BranchAndFinancialInstitutionIdentification5{
+		FinInstnId: FinancialInstitutionIdentification8{BICFI: BICFIIdentifier(bic)},
+	}}
+}
+
+// paymentTypeInformation carries the payment channel and the category
+// purpose, nil when there is neither
+func paymentTypeInformation(channel string, purpose *CategoryPurpose1Choice) *PaymentTypeInformation28 {
+	lclInstrm := localInstrument(channel)
+	if lclInstrm == nil && purpose == nil {
+		return nil
+	}
+	return &PaymentTypeInformation28{LclInstrm: lclInstrm, CtgyPurp: purpose}
+}
+
+// categoryPurpose is the BI-FAST transaction type followed by the purpose
+// code of the channel, e.g. 01002
+func categoryPurpose(txType string, purpose string) *CategoryPurpose1Choice {
+	if purpose == "" {
+		return nil
+	}
+	return &CategoryPurpose1Choice{Prtry: Max35Text(txType + purpose)}
+}
+
+// settlementMethod is method, or CLRG when the channel leaves it out
+func settlementMethod(method string) SettlementMethod1Code {
+	if method == "" {
+		return defaultSettlementMethod
+	}
+	return SettlementMethod1Code(method)
+}
+
+// chargeBearer is bearer, or SLEV when the channel leaves it out
+func chargeBearer(bearer string) ChargeBearerType1Code {
+	if bearer == "" {
+		return defaultChargeBearer
+	}
+	return ChargeBearerType1Code(bearer)
+}
+
+// localInstrument carries the payment channel of the participant
+func localInstrument(channel string) *LocalInstrument2Choice {
+	if channel == "" {
+		return nil
+	}
+	return &LocalInstrument2Choice{Prtry: Max35Text(channel)}
+}
+
+// partyIdentification identifies a customer by organisation id or, for a
+// person, by private id
+func partyIdentification(name string, organizationId string, privateId string) PartyIdentification135 {
+	party := PartyIdentification135{Nm: Max140Text(name)}
+	switch {
+	case organizationId != "":
+		party.Id = &Party38Choice{OrgId: &OrganisationIdentification29{
+			Othr: []GenericOrganisationIdentification1{{Id: Max35Text(organizationId)}},
+		}}
+	case privateId != "":
+		party.Id = &Party38Choice{PrvtId: &PersonIdentification13{
+			Othr: []GenericPersonIdentification1{{Id: Max35Text(privateId)}},
+		}}
+	}
+	return party
+}
+
+// cashAccount identifies an account by its number, accountType is one of
+// the ISO cash account types (CACC, SVGS, ...). Without either there is no
+// account.
+func cashAccount(id string, accountType string) *CashAccount38 {
+	if id == "" && accountType == "" {
+		return nil
+	}
+	acct := &CashAccount38{}
+	if id != "" {
+		acct.Id = &AccountIdentification4Choice{Othr: &GenericAccountIdentification1{Id: Max34Text(id)}}
+	}
+	if accountType != "" {
+		acct.Tp = &CashAccountType2Choice{Cd: ExternalCashAccountType1Code(accountType)}
+	}
+	return acct
+}
+
+// additionalCustomerInfo is the BI-FAST customer type, resident status and
+// town of a debtor or creditor, nil when the channel sent none of them
+func additionalCustomerInfo(tp string, residentStatus string, townName string) *BI_AddtlCstmrInf {
+	if tp == "" && residentStatus == "" && townName == "" {
+		return nil
+	}
+	return &BI_AddtlCstmrInf{
+		Tp:       Max35Text(tp),
+		RsdntSts: Max35Text(residentStatus),
+		TwnNm:    Max35Text(townName),
+	}
+}
+
+func financialInstitution(bic string) BranchAndFinancialInstitutionIdentification6 {
+	return BranchAndFinancialInstitutionIdentification6{
+		FinInstnId: FinancialInstitutionIdentification18{BICFI: BICFIDec2014Identifier(bic)},
+	}
+}
+
+// requireFields fails with every empty field named in fields
+func requireFields(fields map[string]string) error {
+	var missing []string
+	for name, v := range fields {
+		if strings.TrimSpace(v) == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+}
+
+// mapCreditTransferWithProxy turns a PACS008CTwProxy into a pacs.008 whose
+// creditor account is addressed by proxy (phone number, email, ...) in
+// CdtrAcct.Prxy. CdtrAcct.Id stays empty when the channel only knows the
+// proxy, BI-FAST resolves it to the account.
+func mapCreditTransferWithProxy(req PACS008CTwProxy) (BusMsg, error) {
+	if req.Proxycreditoraccountid == "" && req.Creditoraccountid == "" {
+		return BusMsg{}, errors.New("missing creditorAccountId or proxyCreditorAccountId")
+	}
+	if req.Proxycreditoraccountid != "" && req.Proxycreditoraccounttype == "" {
+		return BusMsg{}, errors.New("missing proxyCreditorAccountType")
+	}
+
+	msg, err := mapCreditTransfer(PACS008CreditTransfer{
+		Messageid:                         req.Messageid,
+		Creationdatetime:                  req.Creationdatetime,
+		Numberoftransaction:               req.Numberoftransaction,
+		Settlementmethod:                  req.Settlementmethod,
+		Endtoendid:                        req.Endtoendid,
+		Transactionid:                     req.Transactionid,
+		Paymentchannelid:                  req.Paymentchannelid,
+		Categorypurpose:                   req.Categorypurpose,
+		Interbanksettlementamount:         req.Interbanksettlementamount,
+		Currencycode:                      req.Currencycode,
+		Chargebearer:                      req.Chargebearer,
+		Debtorname:                        req.Debtorname,
+		Debtororganizationid:              req.Debtororganizationid,
+		Debtorprivateid:                   req.Debtorprivateid,
+		Debtoraccountid:                   req.Debtoraccountid,
+		Debtoraccounttype:                 req.Debtoraccounttype,
+		Debtorbankid:                      req.Debtorbankid,
+		Creditorbankid:                    req.Creditorbankid,
+		Creditorname:                      req.Creditorname,
+		Creditororganizationid:            req.Creditororganizationid,
+		Creditorprivateid:                 req.Creditorprivateid,
+		Creditoraccountid:                 req.Creditoraccountid,
+		Creditoraccounttype:               req.Creditoraccounttype,
+		Remittanceinformationunstructured: req.Remittanceinformationunstructured,
+		Debtortype:                        req.Debtortype,
+		Debtorresidentstatus:              req.Debtorresidentstatus,
+		Debtortownname:                    req.Debtortownname,
+		Creditortype:                      req.Creditortype,
+		Creditorresidentstatus:            req.Creditorresidentstatus,
+		Creditortownname:                  req.Creditortownname,
+	})
+	if err != nil {
+		return BusMsg{}, err
+	}
+
+	if req.Proxycreditoraccountid != "" {
+		tx := &msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf[0]
+		if tx.CdtrAcct == nil {
+			tx.CdtrAcct = &CashAccount38{}
+		}
+		tx.CdtrAcct.Prxy = &ProxyAccountIdentification1{
+			Tp: &ProxyAccountType1Choice{Cd: ExternalProxyAccountType1Code(req.Proxycreditoraccounttype)},
+			Id: Max2048Text(req.Proxycreditoraccountid),
+		}
+	}
+	return msg, nil
+}
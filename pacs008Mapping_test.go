@@ -0,0 +1,274 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// creditTransferSample is samples/PACS008CreditTransfer.json, the golden
+// input of the mapping tests
+func creditTransferSample(t *testing.T) PACS008CreditTransfer {
+	t.Helper()
+	var req PACS008CreditTransfer
+	if err := decodeFlatSpec(readSample(t, "PACS008CreditTransfer"), &req, true); err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func TestMapCreditTransfer(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(*PACS008CreditTransfer)
+		wantErr string
+		check   func(*testing.T, FIToFICustomerCreditTransferV08)
+	}{{
+		name: "sample",
+		check: func(t *testing.T, ct FIToFICustomerCreditTransferV08) {
+			tx := ct.CdtTrfTxInf[0]
+			for _, c := range []struct{ name, got, want string }{
+				{"MsgId", string(ct.GrpHdr.MsgId), "20210301INDOIDJA01012345678"},
+				{"CreDtTm", mustMarshalText(t, ct.GrpHdr.CreDtTm), "2021-03-01T19:00:00+07:00"},
+				{"NbOfTxs", string(ct.GrpHdr.NbOfTxs), "1"},
+				{"CtrlSum", ct.GrpHdr.CtrlSum.String(), "1234.56"},
+				{"SttlmMtd", string(ct.GrpHdr.SttlmInf.SttlmMtd), "CLRG"},
+				{"EndToEndId", string(tx.PmtId.EndToEndId), "20210301INDOIDJA010ORB12345678"},
+				{"TxId", string(tx.PmtId.TxId), "20210301INDOIDJA01012345678"},
+				{"LclInstrm", string(tx.PmtTpInf.LclInstrm.Prtry), "01"},
+				{"CtgyPurp", string(tx.PmtTpInf.CtgyPurp.Prtry), "01002"},
+				{"IntrBkSttlmAmt", tx.IntrBkSttlmAmt.Value.String(), "1234.56"},
+				{"Ccy", string(tx.IntrBkSttlmAmt.Ccy), "IDR"},
+				{"ChrgBr", string(tx.ChrgBr), "DEBT"},
+				{"Dbtr", string(tx.Dbtr.Nm), "JAMES BROWN"},
+				{"Dbtr PrvtId", string(tx.Dbtr.Id.PrvtId.Othr[0].Id), "0102030405060708"},
+				{"DbtrAcct", string(tx.DbtrAcct.Id.Othr.Id), "123456789"},
+				{"DbtrAcct Tp", string(tx.DbtrAcct.Tp.Cd), "CACC"},
+				{"DbtrAgt", string(tx.DbtrAgt.FinInstnId.BICFI), "INDOIDJA"},
+				{"CdtrAgt", string(tx.CdtrAgt.FinInstnId.BICFI), "CENAIDJA"},
+				{"Cdtr", string(tx.Cdtr.Nm), "JOHN SMITH"},
+				{"CdtrAcct", string(tx.CdtrAcct.Id.Othr.Id), "987654321"},
+				{"CdtrAcct Tp", string(tx.CdtrAcct.Tp.Cd), "SVGS"},
+				{"RmtInf", string(tx.RmtInf.Ustrd[0]), "Payment Description or notes, up to 140 characters in the line"},
+				{"Dbtr Tp", string(tx.SplmtryData[0].Envlp.Dbtr.Tp), "01"},
+				{"Cdtr TwnNm", string(tx.SplmtryData[0].Envlp.Cdtr.TwnNm), "0300"},
+			} {
+				if c.got != c.want {
+					t.Errorf("%s = %q, want %q", c.name, c.got, c.want)
+				}
+			}
+		},
+	}, {
+		name: "scheme defaults",
+		change: func(r *PACS008CreditTransfer) {
+			r.Settlementmethod, r.Chargebearer, r.Numberoftransaction = "", "", ""
+		},
+		check: func(t *testing.T, ct FIToFICustomerCreditTransferV08) {
+			if m := ct.GrpHdr.SttlmInf.SttlmMtd; m != defaultSettlementMethod {
+				t.Errorf("SttlmMtd = %q, want %q", m, defaultSettlementMethod)
+			}
+			if b := ct.CdtTrfTxInf[0].ChrgBr; b != defaultChargeBearer {
+				t.Errorf("ChrgBr = %q, want %q", b, defaultChargeBearer)
+			}
+			if n := ct.GrpHdr.NbOfTxs; n != "1" {
+				t.Errorf("NbOfTxs = %q, want 1", n)
+			}
+		},
+	}, {
+		name: "without optional parts",
+		change: func(r *PACS008CreditTransfer) {
+			r.Remittanceinformationunstructured = ""
+			r.Debtortype, r.Debtorresidentstatus, r.Debtortownname = "", "", ""
+			r.Creditortype, r.Creditorresidentstatus, r.Creditortownname = "", "", ""
+			r.Debtorprivateid = ""
+			r.Debtororganizationid = "ORG1"
+		},
+		check: func(t *testing.T, ct FIToFICustomerCreditTransferV08) {
+			tx := ct.CdtTrfTxInf[0]
+			if tx.RmtInf != nil || tx.SplmtryData != nil {
+				t.Errorf("RmtInf = %v, SplmtryData = %v, want none", tx.RmtInf, tx.SplmtryData)
+			}
+			if tx.Dbtr.Id.OrgId == nil || tx.Dbtr.Id.PrvtId != nil {
+				t.Errorf("Dbtr Id = %+v, want OrgId only", tx.Dbtr.Id)
+			}
+		},
+	}, {
+		name:    "missing fields",
+		change:  func(r *PACS008CreditTransfer) { r.Creationdatetime, r.Debtorbankid = "", "" },
+		wantErr: "missing creationDateTime, debtorBankId",
+	}, {
+		name:    "bad creation time",
+		change:  func(r *PACS008CreditTransfer) { r.Creationdatetime = "01-03-2021 19:00" },
+		wantErr: "creationDateTime",
+	}, {
+		name:    "bad amount",
+		change:  func(r *PACS008CreditTransfer) { r.Interbanksettlementamount = "12,34" },
+		wantErr: "InterBankSettlementAmount",
+	}, {
+		name:    "too many fraction digits",
+		change:  func(r *PACS008CreditTransfer) { r.Interbanksettlementamount = "1.001" },
+		wantErr: "InterBankSettlementAmount",
+	}, {
+		name:    "number of transactions not 1",
+		change:  func(r *PACS008CreditTransfer) { r.Numberoftransaction = "5" },
+		wantErr: "numberOfTransaction 5, the request has 1 transaction(s)",
+	}, {
+		name:    "number of transactions 0",
+		change:  func(r *PACS008CreditTransfer) { r.Numberoftransaction = "0" },
+		wantErr: "numberOfTransaction 0",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := creditTransferSample(t)
+			if tt.change != nil {
+				tt.change(&req)
+			}
+
+			msg, err := mapCreditTransfer(req)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := msg.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+			if k := msg.Document.Kind(); k != kindPacs008 {
+				t.Fatalf("Kind = %s, want %s", k, kindPacs008)
+			}
+			if bic := msg.AppHdr.Fr.FIId.FinInstnId.BICFI; bic != BICFIIdentifier(req.Debtorbankid) {
+				t.Errorf("AppHdr Fr = %s, want %s", bic, req.Debtorbankid)
+			}
+			tt.check(t, *msg.Document.FIToFICstmrCdtTrf)
+		})
+	}
+}
+
+func mustMarshalText(t *testing.T, v interface{ MarshalText() ([]byte, error) }) string {
+	t.Helper()
+	text, err := v.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(text)
+}
+
+func TestMapCreditTransferWithProxy(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   func(*PACS008CTwProxy)
+		wantErr  string
+		wantId   string
+		wantPrxy string
+	}{{
+		name:   "sample",
+		wantId: "987654321",
+	}, {
+		name: "account and proxy",
+		change: func(r *PACS008CTwProxy) {
+			r.Proxycreditoraccounttype, r.Proxycreditoraccountid = "01", "081234567890"
+		},
+		wantId:   "987654321",
+		wantPrxy: "081234567890",
+	}, {
+		name: "proxy only",
+		change: func(r *PACS008CTwProxy) {
+			r.Creditoraccountid, r.Creditoraccounttype = "", ""
+			r.Proxycreditoraccounttype, r.Proxycreditoraccountid = "02", "john.smith@example.com"
+		},
+		wantPrxy: "john.smith@example.com",
+	}, {
+		name:    "neither account nor proxy",
+		change:  func(r *PACS008CTwProxy) { r.Creditoraccountid = "" },
+		wantErr: "missing creditorAccountId or proxyCreditorAccountId",
+	}, {
+		name:    "proxy without type",
+		change:  func(r *PACS008CTwProxy) { r.Proxycreditoraccountid = "081234567890" },
+		wantErr: "missing proxyCreditorAccountType",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req PACS008CTwProxy
+			if err := decodeFlatSpec(readSample(t, "PACS008CTwProxy"), &req, true); err != nil {
+				t.Fatal(err)
+			}
+			if tt.change != nil {
+				tt.change(&req)
+			}
+
+			msg, err := mapCreditTransferWithProxy(req)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := msg.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+
+			// through XML and back, as the adapter sees it
+			data, err := marshalBusMsgXML(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if empty := emptyElements(data); len(empty) > 0 {
+				t.Errorf("empty elements %s in\n%s", strings.Join(empty, ", "), data)
+			}
+			if msg, err = parseBusMsgXML(data); err != nil {
+				t.Fatal(err)
+			}
+
+			tx := msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf[0]
+			var id string
+			if tx.CdtrAcct.Id != nil && tx.CdtrAcct.Id.Othr != nil {
+				id = string(tx.CdtrAcct.Id.Othr.Id)
+			}
+			if id != tt.wantId {
+				t.Errorf("CdtrAcct Id = %q, want %q", id, tt.wantId)
+			}
+			var prxy string
+			if tx.CdtrAcct.Prxy != nil {
+				prxy = string(tx.CdtrAcct.Prxy.Id)
+				if tx.CdtrAcct.Prxy.Tp.Cd != ExternalProxyAccountType1Code(req.Proxycreditoraccounttype) {
+					t.Errorf("Prxy Tp = %q, want %q", tx.CdtrAcct.Prxy.Tp.Cd, req.Proxycreditoraccounttype)
+				}
+			}
+			if prxy != tt.wantPrxy {
+				t.Errorf("Prxy Id = %q, want %q", prxy, tt.wantPrxy)
+			}
+			if tx.Cdtr.Nm != Max140Text(req.Creditorname) || tx.IntrBkSttlmAmt.Value.String() != req.Interbanksettlementamount {
+				t.Errorf("Cdtr %q amount %s, want %q %s", tx.Cdtr.Nm, tx.IntrBkSttlmAmt.Value.String(), req.Creditorname, req.Interbanksettlementamount)
+			}
+		})
+	}
+}
+
+// every flat request is one transaction, whatever numberOfTransaction says
+func TestNumberOfTransactionsMismatch(t *testing.T) {
+	var fi PACS009FICreditTransfer
+	if err := decodeFlatSpec(readSample(t, "PACS009FICreditTransfer"), &fi, true); err != nil {
+		t.Fatal(err)
+	}
+	fi.Numberoftransaction = "2"
+	if _, err := mapFICreditTransfer(fi); err == nil || !strings.Contains(err.Error(), "numberOfTransaction 2") {
+		t.Errorf("pacs.009 with numberOfTransaction 2: err = %v", err)
+	}
+
+	var enq PACS008AccEnq
+	if err := decodeFlatSpec(readSample(t, "PACS008AccEnq"), &enq, true); err != nil {
+		t.Fatal(err)
+	}
+	enq.Numbertransaction = "3"
+	if _, err := mapAccountEnquiry(enq); err == nil || !strings.Contains(err.Error(), "NumberTransaction 3") {
+		t.Errorf("account enquiry with NumberTransaction 3: err = %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+)
+
+// transaction type of a transfer between participants on their own account
+const fiCreditTransferTxType = "019"
+
+// mapFICreditTransfer turns the flat PACS009FICreditTransfer of the channel
+// into the ISO 20022 pacs.009. Debtor and creditor are the institutions, the
+// customer transfer it covers goes to UndrlygCstmrCdtTrf.
+func mapFICreditTransfer(req PACS009FICreditTransfer) (BusMsg, error) {
+	if err := requireFields(map[string]string{
+		"creationDateTime":          req.Creationdatetime,
+		"InterBankSettlementAmount": req.Interbanksettlementamount,
+		"currencyCode":              req.Currencycode,
+		"debtorInstitutionId":       req.Debtorinstitutionid,
+		"creditorInstitutionId":     req.Creditorinstitutionid,
+	}); err != nil {
+		return BusMsg{}, err
+	}
+
+	var creDtTm ISODateTime
+	if err := creDtTm.UnmarshalText([]byte(req.Creationdatetime)); err != nil {
+		return BusMsg{}, fmt.Errorf("creationDateTime: %v", err)
+	}
+
+	amount, err := parsePaymentAmount(req.Interbanksettlementamount, req.Currencycode)
+	if err != nil {
+		return BusMsg{}, fmt.Errorf("InterBankSettlementAmount: %v", err)
+	}
+
+	// the flat request is one transaction
+	nbOfTxs, err := numberOfTransactions("numberOfTransaction", req.Numberoftransaction, 1)
+	if err != nil {
+		return BusMsg{}, err
+	}
+
+	tx := CreditTransferTransaction44{
+		PmtId: PaymentIdentification13{
+			EndToEndId: Max35Text(req.Endtoendid),
+			TxId:       Max35Text(req.Transactionid),
+		},
+		PmtTpInf: paymentTypeInformation(req.Paymentchannelid, categoryPurpose(fiCreditTransferTxType, req.Categorypurpose)),
+		IntrBkSttlmAmt: ActiveCurrencyAndAmount{
+			Value: amount,
+			Ccy:   ActiveCurrencyCode(req.Currencycode),
+		},
+		Dbtr:     financialInstitution(req.Debtorinstitutionid),
+		DbtrAcct: cashAccount(req.Debtoraccountid, req.Debtoraccounttype),
+		Cdtr:     financialInstitution(req.Creditorinstitutionid),
+		CdtrAcct: cashAccount(req.Creditoraccountid, req.Creditoraccounttype),
+	}
+
+	if req.Interbanksettlementdate != "" {
+		var sttlmDt ISODate
+		if err := sttlmDt.UnmarshalText([]byte(req.Interbanksettlementdate)); err != nil {
+			return BusMsg{}, fmt.Errorf("interBankSettlementDate: %v", err)
+		}
+		tx.IntrBkSttlmDt = &sttlmDt
+	}
+	if req.Remittanceinformationunstructured != "" {
+		tx.RmtInf = &RemittanceInformation2{Ustrd: []Max140Text{Max140Text(req.Remittanceinformationunstructured)}}
+	}
+
+	undrlyg, err := underlyingCustomerCreditTransfer(req)
+	if err != nil {
+		return BusMsg{}, err
+	}
+	tx.UndrlygCstmrCdtTrf = undrlyg
+
+	msg := BusMsg{
+		AppHdr: appHdr(req.Debtorinstitutionid, req.Messageid, pacs009MsgDefIdr, creDtTm),
+	}
+	msg.Document.FICdtTrf = &FinancialInstitutionCreditTransferV09{
+		GrpHdr: GroupHeader93{
+			MsgId:    Max35Text(req.Messageid),
+			CreDtTm:  creDtTm,
+			NbOfTxs:  nbOfTxs,
+			SttlmInf: SettlementInstruction7{SttlmMtd: settlementMethod(req.Settlementmethod)},
+		},
+		CdtTrfTxInf: []CreditTransferTransaction44{tx},
+	}
+
+	sum, err := controlSum([]ActiveCurrencyAndAmount{tx.IntrBkSttlmAmt})
+	if err != nil {
+		return BusMsg{}, err
+	}
+	msg.Document.FICdtTrf.GrpHdr.CtrlSum = sum
+	return msg, nil
+}
+
+// underlyingCustomerCreditTransfer is the customer transfer a pacs.009 covers,
+// nil when the channel sent none of the underlying* fields
+func underlyingCustomerCreditTransfer(req PACS009FICreditTransfer) (*CreditTransferTransaction45, error) {
+	if req.Underlyingdebtorname == "" && req.Underlyingdebtoraccountid == "" && req.Underlyingdebtorbankid == "" &&
+		req.Underlyingcreditorname == "" && req.Underlyingcreditoraccountid == "" && req.Underlyingcreditorbankid == "" &&
+		req.Underlyingremittanceinformationunstructured == "" {
+		return nil, nil
+	}
+	if err := requireFields(map[string]string{
+		"underlyingDebtorName":     req.Underlyingdebtorname,
+		"underlyingDebtorBankId":   req.Underlyingdebtorbankid,
+		"underlyingCreditorName":   req.Underlyingcreditorname,
+		"underlyingCreditorBankId": req.Underlyingcreditorbankid,
+	}); err != nil {
+		return nil, err
+	}
+
+	undrlyg := &CreditTransferTransaction45{
+		Dbtr:     partyIdentification(req.Underlyingdebtorname, "", ""),
+		DbtrAcct: cashAccount(req.Underlyingdebtoraccountid, ""),
+		DbtrAgt:  financialInstitution(req.Underlyingdebtorbankid),
+		CdtrAgt:  financialInstitution(req.Underlyingcreditorbankid),
+		Cdtr:     partyIdentification(req.Underlyingcreditorname, "", ""),
+		CdtrAcct: cashAccount(req.Underlyingcreditoraccountid, ""),
+	}
+	if req.Underlyingremittanceinformationunstructured != "" {
+		undrlyg.RmtInf = &RemittanceInformation16{Ustrd: []Max140Text{Max140Text(req.Underlyingremittanceinformationunstructured)}}
+	}
+	return undrlyg, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	errRegistryFull     = errors.New("too many requests waiting for response")
+	errDuplicateRequest = errors.New("request with the same correlation id is already waiting")
+	errResponseTimeout  = errors.New("timed out waiting for response")
+	errDeliveryFailed   = errors.New("delivery failed")
+)
+
+// pendingRegistry pairs requests sent to Kafka with the responses consumed
+// from the response topic. Every request registers a waiter under its
+// correlation id (`resConsume.Head`) and kafkaConsumer hands the response
+// straight to that waiter, so nobody has to poll.
+type pendingRegistry struct {
+	mu      sync.Mutex
+	waiters map[string]chan pendingResult
+	limit   int
+}
+
+// pendingResult is either the response or the reason there won't be one
+type pendingResult struct {
+	msg resConsume
+	err error
+}
+
+// pendingRequest is the waiter side of a registered correlation id
+type pendingRequest struct {
+	head     string
+	response chan pendingResult
+	registry *pendingRegistry
+}
+
+func newPendingRegistry(limit int) *pendingRegistry {
+	return &pendingRegistry{
+		waiters: make(map[string]chan pendingResult),
+		limit:   limit,
+	}
+}
+
+// register reserves a waiter for head. It must be called before the request
+// is produced, otherwise a fast response could arrive with nobody waiting.
+func (r *pendingRegistry) register(head string) (*pendingRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.waiters[head]; ok {
+		return nil, errDuplicateRequest
+	}
+	if r.limit > 0 && len(r.waiters) >= r.limit {
+		return nil, errRegistryFull
+	}
+
+	// buffered so deliver never blocks on a waiter that is about to give up
+	ch := make(chan pendingResult, 1)
+	r.waiters[head] = ch
+
+	return &pendingRequest{head: head, response: ch, registry: r}, nil
+}
+
+// deliver hands msg to the request waiting for msg.Head. It reports false
+// when nobody is waiting, e.g. the request already timed out.
+func (r *pendingRegistry) deliver(msg resConsume) bool {
+	return r.resolve(msg.Head, pendingResult{msg: msg})
+}
+
+// fail ends the wait of head with err, e.g. when the request could not be
+// delivered to Kafka and no response will ever come
+func (r *pendingRegistry) fail(head string, err error) bool {
+	return r.resolve(head, pendingResult{err: err})
+}
+
+func (r *pendingRegistry) resolve(head string, res pendingResult) bool {
+	r.mu.Lock()
+	ch, ok := r.waiters[head]
+	if ok {
+		delete(r.waiters, head)
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+	ch <- res
+	return true
+}
+
+// remove drops the waiter of head unless it was already replaced
+func (r *pendingRegistry) remove(head string, ch chan pendingResult) {
+	r.mu.Lock()
+	if r.waiters[head] == ch {
+		delete(r.waiters, head)
+	}
+	r.mu.Unlock()
+}
+
+// size returns the number of requests still waiting for response
+func (r *pendingRegistry) size() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.waiters)
+}
+
+// wait blocks until the response arrives, the request fails or ctx is done. Use a ctx with
+// deadline for the per-request timeout and derive it from the connection
+// ctx so a dropped connection releases its waiters.
+func (p *pendingRequest) wait(ctx context.Context) (resConsume, error) {
+	select {
+	case res := <-p.response:
+		return res.msg, res.err
+	case <-ctx.Done():
+		p.registry.remove(p.head, p.response)
+
+		// the response may have been delivered right before remove
+		select {
+		case res := <-p.response:
+			return res.msg, res.err
+		default:
+		}
+
+		if ctx.Err() == context.DeadlineExceeded {
+			return resConsume{}, errResponseTimeout
+		}
+		return resConsume{}, ctx.Err()
+	}
+}
+
+// cancel releases the waiter without waiting for response
+func (p *pendingRequest) cancel() {
+	p.registry.remove(p.head, p.response)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPendingRegistryConcurrentDeliver(t *testing.T) {
+	const n = 3000 // two goroutines each, below the race detector limit of 8128
+	r := newPendingRegistry(n)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		head := fmt.Sprintf("H%07d", i)
+		p, err := r.register(head)
+		if err != nil {
+			t.Fatalf("register %s: %v", head, err)
+		}
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			msg, err := p.wait(ctx)
+			if err != nil {
+				errs <- fmt.Errorf("%s: %v", head, err)
+				return
+			}
+			if msg.Head != head || msg.Content != "response "+head {
+				errs <- fmt.Errorf("%s got response of %s: %q", head, msg.Head, msg.Content)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if !r.deliver(resConsume{Head: head, Content: "response " + head}) {
+				errs <- fmt.Errorf("%s: nobody waiting", head)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if size := r.size(); size != 0 {
+		t.Errorf("size after all delivered = %d, want 0", size)
+	}
+}
+
+func TestPendingRegistryTimeoutAndCancel(t *testing.T) {
+	const n = 2000
+	r := newPendingRegistry(0)
+	connCtx, dropConn := context.WithCancel(context.Background())
+
+	var wg, timedOut sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		head := fmt.Sprintf("H%07d", i)
+		p, err := r.register(head)
+		if err != nil {
+			t.Fatalf("register %s: %v", head, err)
+		}
+
+		wg.Add(1)
+		switch i % 3 {
+		case 0: // runs into its deadline, before the connection drops
+			ctx, cancel := context.WithTimeout(connCtx, 10*time.Millisecond)
+			timedOut.Add(1)
+			go func() {
+				defer wg.Done()
+				defer timedOut.Done()
+				defer cancel()
+				if _, err := p.wait(ctx); err != errResponseTimeout {
+					errs <- fmt.Errorf("%s: err = %v, want %v", head, err, errResponseTimeout)
+				}
+			}()
+		case 1: // released by the connection dropping
+			go func() {
+				defer wg.Done()
+				if _, err := p.wait(connCtx); err != context.Canceled {
+					errs <- fmt.Errorf("%s: err = %v, want %v", head, err, context.Canceled)
+				}
+			}()
+		case 2: // given up before waiting
+			go func() {
+				defer wg.Done()
+				p.cancel()
+			}()
+		}
+	}
+
+	timedOut.Wait()
+	dropConn()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if size := r.size(); size != 0 {
+		t.Errorf("size after all released = %d, want 0", size)
+	}
+	if r.deliver(resConsume{Head: "H0000000"}) {
+		t.Error("late response delivered to a request that timed out")
+	}
+}
+
+func TestPendingRegistryBound(t *testing.T) {
+	r := newPendingRegistry(2)
+
+	a, err := r.register("A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.register("A"); err != errDuplicateRequest {
+		t.Errorf("register twice: err = %v, want %v", err, errDuplicateRequest)
+	}
+	if _, err := r.register("B"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.register("C"); err != errRegistryFull {
+		t.Errorf("register over limit: err = %v, want %v", err, errRegistryFull)
+	}
+
+	// a released waiter frees its place
+	a.cancel()
+	if _, err := r.register("C"); err != nil {
+		t.Errorf("register after cancel: %v", err)
+	}
+}
+
+func TestPendingRegistryFail(t *testing.T) {
+	r := newPendingRegistry(0)
+	p, err := r.register("A")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failure := fmt.Errorf("delivery failed")
+	if !r.fail("A", failure) {
+		t.Fatal("fail found nobody waiting")
+	}
+	if _, err := p.wait(context.Background()); err != failure {
+		t.Errorf("wait: err = %v, want %v", err, failure)
+	}
+}
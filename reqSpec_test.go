@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding"
+	"testing"
+	"time"
+)
+
+// every lexical form the channel and the adapter may send: Z, an offset,
+// no zone (local to biFastZone) and fractional seconds
+func TestTimeLexicalForms(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*3600)
+	tests := []struct {
+		name string
+		v    interface {
+			encoding.TextUnmarshaler
+			encoding.TextMarshaler
+		}
+		in   string
+		want time.Time // the instant, compared with Equal
+		text string    // marshalled again
+	}{
+		{"ISODateTime Z", new(ISODateTime), "2021-03-01T12:00:00Z", time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), "2021-03-01T12:00:00Z"},
+		{"ISODateTime +07:00", new(ISODateTime), "2021-03-01T19:00:00+07:00", time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), "2021-03-01T19:00:00+07:00"},
+		{"ISODateTime -05:30", new(ISODateTime), "2021-03-01T06:30:00-05:30", time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), "2021-03-01T06:30:00-05:30"},
+		{"ISODateTime no zone", new(ISODateTime), "2021-03-01T19:00:00", time.Date(2021, 3, 1, 19, 0, 0, 0, wib), "2021-03-01T19:00:00+07:00"},
+		{"ISODateTime fraction", new(ISODateTime), "2021-03-01T19:00:00.123+07:00", time.Date(2021, 3, 1, 19, 0, 0, 123000000, wib), "2021-03-01T19:00:00.123+07:00"},
+		{"ISODateTime nanoseconds", new(ISODateTime), "2021-03-01T12:00:00.123456789Z", time.Date(2021, 3, 1, 12, 0, 0, 123456789, time.UTC), "2021-03-01T12:00:00.123456789Z"},
+		{"ISODateTime trailing zeros", new(ISODateTime), "2021-03-01T12:00:00.500Z", time.Date(2021, 3, 1, 12, 0, 0, 500000000, time.UTC), "2021-03-01T12:00:00.5Z"},
+		{"ISODateTime fraction no zone", new(ISODateTime), "2021-03-01T19:00:00.25", time.Date(2021, 3, 1, 19, 0, 0, 250000000, wib), "2021-03-01T19:00:00.25+07:00"},
+		{"ISODateTime spaces", new(ISODateTime), " 2021-03-01T12:00:00Z\n", time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), "2021-03-01T12:00:00Z"},
+
+		{"ISONormalisedDateTime Z", new(ISONormalisedDateTime), "2021-03-01T12:00:00Z", time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), "2021-03-01T12:00:00Z"},
+		{"ISONormalisedDateTime +07:00", new(ISONormalisedDateTime), "2021-03-01T19:00:00+07:00", time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), "2021-03-01T12:00:00Z"},
+		{"ISONormalisedDateTime no zone", new(ISONormalisedDateTime), "2021-03-01T19:00:00", time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), "2021-03-01T12:00:00Z"},
+		{"ISONormalisedDateTime fraction", new(ISONormalisedDateTime), "2021-03-02T00:30:00.75+07:00", time.Date(2021, 3, 1, 17, 30, 0, 750000000, time.UTC), "2021-03-01T17:30:00.75Z"},
+
+		{"ISODate", new(ISODate), "2021-03-01", time.Date(2021, 3, 1, 0, 0, 0, 0, wib), "2021-03-01"},
+		{"ISODate Z", new(ISODate), "2021-03-01Z", time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), "2021-03-01"},
+		{"ISODate +07:00", new(ISODate), "2021-03-01+07:00", time.Date(2021, 3, 1, 0, 0, 0, 0, wib), "2021-03-01"},
+		{"ISODate -10:00", new(ISODate), "2021-03-01-10:00", time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC), "2021-03-01"},
+
+		{"ISOTime", new(ISOTime), "19:00:00", time.Date(2000, 1, 1, 19, 0, 0, 0, wib), "19:00:00+07:00"},
+		{"ISOTime Z", new(ISOTime), "12:00:00Z", time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), "12:00:00Z"},
+		{"ISOTime +07:00", new(ISOTime), "19:00:00+07:00", time.Date(2000, 1, 1, 19, 0, 0, 0, wib), "19:00:00+07:00"},
+		{"ISOTime fraction", new(ISOTime), "19:00:00.001+07:00", time.Date(2000, 1, 1, 19, 0, 0, 1000000, wib), "19:00:00.001+07:00"},
+		{"ISOTime fraction no zone", new(ISOTime), "23:59:59.999", time.Date(2000, 1, 1, 23, 59, 59, 999000000, wib), "23:59:59.999+07:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.v.UnmarshalText([]byte(tt.in)); err != nil {
+				t.Fatalf("UnmarshalText(%q): %v", tt.in, err)
+			}
+			var got time.Time
+			switch v := tt.v.(type) {
+			case *ISODateTime:
+				got = time.Time(*v)
+			case *ISONormalisedDateTime:
+				got = time.Time(*v)
+			case *ISODate:
+				got = time.Time(*v)
+			case *ISOTime:
+				got = time.Time(*v)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("UnmarshalText(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+			text, err := tt.v.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(text) != tt.text {
+				t.Errorf("MarshalText = %s, want %s", text, tt.text)
+			}
+		})
+	}
+}
+
+func TestTimeLexicalFormsRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		v    encoding.TextUnmarshaler
+		in   string
+	}{
+		{"ISODateTime date only", new(ISODateTime), "2021-03-01"},
+		{"ISODateTime no seconds", new(ISODateTime), "2021-03-01T19:00"},
+		{"ISODateTime space", new(ISODateTime), "2021-03-01 19:00:00"},
+		{"ISODateTime offset without colon", new(ISODateTime), "2021-03-01T19:00:00+0700"},
+		{"ISODateTime lower z", new(ISODateTime), "2021-03-01T12:00:00z"},
+		{"ISODateTime empty fraction", new(ISODateTime), "2021-03-01T12:00:00.Z"},
+		{"ISODateTime month 13", new(ISODateTime), "2021-13-01T12:00:00Z"},
+		{"ISODateTime empty", new(ISODateTime), ""},
+		{"ISONormalisedDateTime date only", new(ISONormalisedDateTime), "2021-03-01"},
+		{"ISODate with time", new(ISODate), "2021-03-01T00:00:00"},
+		{"ISODate compact", new(ISODate), "20210301"},
+		{"ISODate February 30", new(ISODate), "2021-02-30"},
+		{"ISOTime no seconds", new(ISOTime), "19:00"},
+		{"ISOTime hour 24", new(ISOTime), "24:00:00"},
+		{"ISOTime with date", new(ISOTime), "2021-03-01T19:00:00"},
+	}
+
+	for _, tt := range tests {
+		if err := tt.v.UnmarshalText([]byte(tt.in)); err == nil {
+			t.Errorf("%s: UnmarshalText(%q) without error", tt.name, tt.in)
+		}
+	}
+}
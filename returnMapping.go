@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+const (
+	// transaction type of a credit transfer returning a received one, BI-FAST
+	// links it to the original by OrgnlEndtoEndId in the supplementary data
+	creditTransferReturnTxType = "011"
+
+	// purpose of a return when neither the channel nor the original has one,
+	// 99 is others
+	defaultReturnPurpose = "99"
+)
+
+// mapCreditTransferReturn turns the flat PACS008CTReturn of the channel into
+// a pacs.008 from the creditor of the original back to its debtor, with the
+// charge bearer of the original unless the channel gives one. The
+// original must be in the transaction store and the return must not take
+// more than is left of it.
+func mapCreditTransferReturn(req PACS008CTReturn) (BusMsg, error) {
+	if err := requireFields(map[string]string{
+		"creationDateTime":          req.Creationdatetime,
+		"originalEndToEndId":        req.Originalendtoendid,
+		"InterBankSettlementAmount": req.Interbanksettlementamount,
+		"currencyCode":              req.Currencycode,
+	}); err != nil {
+		return BusMsg{}, err
+	}
+
+	orig, returned, err := transactions.original(req.Originalendtoendid)
+	if err != nil {
+		return BusMsg{}, fmt.Errorf("originalEndToEndId %s: %v", req.Originalendtoendid, err)
+	}
+	if req.Currencycode != string(orig.IntrBkSttlmAmt.Ccy) {
+		return BusMsg{}, fmt.Errorf("currencyCode %s, the original is in %s", req.Currencycode, orig.IntrBkSttlmAmt.Ccy)
+	}
+
+	var creDtTm ISODateTime
+	if err := creDtTm.UnmarshalText([]byte(req.Creationdatetime)); err != nil {
+		return BusMsg{}, fmt.Errorf("creationDateTime: %v", err)
+	}
+
+	amount, err := parsePaymentAmount(req.Interbanksettlementamount, req.Currencycode)
+	if err != nil {
+		return BusMsg{}, fmt.Errorf("InterBankSettlementAmount: %v", err)
+	}
+	if err := checkReturn(orig, returned, amount); err != nil {
+		return BusMsg{}, fmt.Errorf("InterBankSettlementAmount: %v", err)
+	}
+
+	// the purpose of the original unless the channel gives another
+	purpose := req.Categorypurpose
+	if purpose == "" && orig.PmtTpInf != nil && orig.PmtTpInf.CtgyPurp != nil && len(orig.PmtTpInf.CtgyPurp.Prtry) > 3 {
+		purpose = string(orig.PmtTpInf.CtgyPurp.Prtry[3:])
+	}
+	if purpose == "" {
+		// BI-FAST tells a return by its CtgyPurp
+		purpose = defaultReturnPurpose
+	}
+
+	tx := CreditTransferTransaction39{
+		PmtId: PaymentIdentification7{
+			EndToEndId: Max35Text(req.Endtoendid),
+			TxId:       Max35Text(req.Transactionid),
+		},
+		PmtTpInf: paymentTypeInformation(req.Paymentchannelid, categoryPurpose(creditTransferReturnTxType, purpose)),
+		IntrBkSttlmAmt: ActiveCurrencyAndAmount{
+			Value: amount,
+			Ccy:   orig.IntrBkSttlmAmt.Ccy,
+		},
+		ChrgBr:   chargeBearer(string(orig.ChrgBr)),
+		Dbtr:     orig.Cdtr,
+		DbtrAcct: returnAccount(orig.CdtrAcct),
+		DbtrAgt:  orig.CdtrAgt,
+		CdtrAgt:  orig.DbtrAgt,
+		Cdtr:     orig.Dbtr,
+		CdtrAcct: returnAccount(orig.DbtrAcct),
+	}
+	if req.Chargebearer != "" {
+		tx.ChrgBr = ChargeBearerType1Code(req.Chargebearer)
+	}
+	if req.Remittanceinformationunstructured != "" {
+		tx.RmtInf = &RemittanceInformation16{Ustrd: []Max140Text{Max140Text(req.Remittanceinformationunstructured)}}
+	}
+
+	envlp := BI_SupplementaryDataEnvelope1{OrgnlEndtoEndId: Max34Text(req.Originalendtoendid)}
+	if len(orig.SplmtryData) > 0 {
+		envlp.Dbtr, envlp.Cdtr = orig.SplmtryData[0].Envlp.Cdtr, orig.SplmtryData[0].Envlp.Dbtr
+	}
+	tx.SplmtryData = []BI_SupplementaryData1{{Envlp: envlp}}
+
+	from := string(orig.CdtrAgt.FinInstnId.BICFI)
+	msg := BusMsg{
+		AppHdr: appHdr(from, req.Messageid, pacs008MsgDefIdr, creDtTm),
+	}
+	msg.Document.FIToFICstmrCdtTrf = &FIToFICustomerCreditTransferV08{
+		GrpHdr: GroupHeader93{
+			MsgId:    Max35Text(req.Messageid),
+			CreDtTm:  creDtTm,
+			NbOfTxs:  "1",
+			SttlmInf: SettlementInstruction7{SttlmMtd: settlementMethod(req.Settlementmethod)},
+		},
+		CdtTrfTxInf: []CreditTransferTransaction39{tx},
+	}
+	if err := setControlSum(msg.Document.FIToFICstmrCdtTrf); err != nil {
+		return BusMsg{}, err
+	}
+	return msg, nil
+}
+
+// returnAccount is an account of the original addressed by its id, a proxy
+// the original was sent to is resolved by then
+func returnAccount(acct *CashAccount38) *CashAccount38 {
+	if acct == nil {
+		return nil
+	}
+	ret := *acct
+	ret.Prxy = nil
+	return &ret
+}
+
+// recordReturn keeps the return msg in the transaction store right before it
+// is sent. The store checks the amount again, another return of the same
+// original may have been recorded since mapCreditTransferReturn. A return
+// that is not sent after all is released again, see releaseReturn.
+func recordReturn(msg BusMsg) error {
+	ct := msg.Document.FIToFICstmrCdtTrf
+	if ct == nil || len(ct.CdtTrfTxInf) != 1 || len(ct.CdtTrfTxInf[0].SplmtryData) == 0 {
+		return errors.New("return without CdtTrfTxInf and OrgnlEndtoEndId")
+	}
+	tx := ct.CdtTrfTxInf[0]
+	orig := string(tx.SplmtryData[0].Envlp.OrgnlEndtoEndId)
+	if err := transactions.addReturn(string(tx.PmtId.EndToEndId), orig, tx.IntrBkSttlmAmt.Value); err != nil {
+		return fmt.Errorf("originalEndToEndId %s: %v", orig, err)
+	}
+	return nil
+}
+
+// releaseReturn gives back to its original what msg, a request of kind, took
+// with recordReturn when it did not reach BI-FAST
+func releaseReturn(kind requestKind, msg BusMsg) {
+	if kind != kindCreditTransferReturn || msg.Document.FIToFICstmrCdtTrf == nil {
+		return
+	}
+	for _, tx := range msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf {
+		if err := transactions.rejectReturn(string(tx.PmtId.EndToEndId)); err != nil {
+			log.Printf("Fail to release return %s: %v\n", tx.PmtId.EndToEndId, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+// receiveOriginal keeps the sample credit transfer in the transaction store
+// as received, changed by change when given, and returns its EndToEndId
+func receiveOriginal(t *testing.T, change func(*CreditTransferTransaction39)) string {
+	t.Helper()
+	msg, err := mapCreditTransfer(creditTransferSample(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf[0]
+	if change != nil {
+		change(&tx)
+	}
+	if err := transactions.received([]CreditTransferTransaction39{tx}); err != nil {
+		t.Fatal(err)
+	}
+	return string(tx.PmtId.EndToEndId)
+}
+
+func TestCreditTransferReturnPurpose(t *testing.T) {
+	noPurpose := func(tx *CreditTransferTransaction39) { tx.PmtTpInf = nil }
+	tests := []struct {
+		name    string
+		change  func(*CreditTransferTransaction39)
+		purpose string // of the channel
+		want    string
+	}{
+		{"of the original", nil, "", "01102"},
+		{"of the channel", nil, "03", "01103"},
+		{"of the channel, original without", noPurpose, "03", "01103"},
+		{"default", noPurpose, "", "011" + defaultReturnPurpose},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if transactions, err = openTransactionStore(filepath.Join(t.TempDir(), "netChannel.tx")); err != nil {
+				t.Fatal(err)
+			}
+			defer transactions.Close()
+			orig := receiveOriginal(t, tt.change)
+
+			msg, err := mapCreditTransferReturn(PACS008CTReturn{
+				Creationdatetime:          "2021-03-02T10:00:00",
+				Originalendtoendid:        orig,
+				Interbanksettlementamount: "1000.00",
+				Currencycode:              "IDR",
+				Categorypurpose:           tt.purpose,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf[0].PmtTpInf
+			if p == nil || p.CtgyPurp == nil || string(p.CtgyPurp.Prtry) != tt.want {
+				t.Errorf("PmtTpInf = %+v, want CtgyPurp %s", p, tt.want)
+			}
+		})
+	}
+}
+
+// a return counts against its original only once it is sent
+func TestCreditTransferReturnNotSent(t *testing.T) {
+	tests := []struct {
+		name     string
+		breakBus gatewayOption
+		status   string
+		returned string
+	}{
+		{"sent", nil, "ACSC", "1000.00"},
+		{"registry full", withFullRegistry, "RJCT", "0"},
+		{"publish fails", withClosedBus, "RJCT", "0"},
+		{"delivery fails", withFailingDelivery, "RJCT", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []gatewayOption
+			if tt.breakBus != nil {
+				opts = append(opts, tt.breakBus)
+			}
+			addr := startGateway(t, settleAll, opts...)
+			orig := receiveOriginal(t, nil)
+
+			request := `{"msgType": "PACS008CTReturn", "creationDateTime": "2021-03-02T10:00:00",
+				"originalEndToEndId": "` + orig + `", "InterBankSettlementAmount": "1000.00", "currencyCode": "IDR"}`
+			// the reply comes after the return is recorded or released
+			var res PACS002StatusResponse
+			reply := exchange(t, addr, []byte(request))
+			if err := json.Unmarshal(reply, &res); err != nil {
+				t.Fatalf("reply %s: %v", reply, err)
+			}
+			if res.Status != tt.status {
+				t.Errorf("reply = %s, want %s", reply, tt.status)
+			}
+
+			_, returned, err := transactions.original(orig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if returned.String() != tt.returned {
+				t.Errorf("returned of the original = %s, want %s", returned, tt.returned)
+			}
+		})
+	}
+}
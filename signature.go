@@ -0,0 +1,242 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"golang.org/x/crypto/pkcs12"
+)
+
+// status reasons of a message failing signature verification
+const (
+	reasonSignatureMissing = "DS0A"
+	reasonSignatureInvalid = "DS01"
+)
+
+// signatureError is a message whose signature does not verify, Reason is
+// the ISO status reason reported to the channel
+type signatureError struct {
+	Reason string
+	Err    error
+}
+
+func (e *signatureError) Error() string {
+	return fmt.Sprintf("signature %s: %v", e.Reason, e.Err)
+}
+
+// signatureService signs outgoing BusMsg with the key of the participant and
+// verifies incoming ones against the certificates trusted for the BIC in
+// AppHdr.Fr. The signature is an enveloped XMLDSig (exclusive C14N,
+// RSA-SHA256) over the whole BusMsg, kept in AppHdr.Sgntr.
+//
+// Signing covers the XML of marshalBusMsgXML without indentation, so the
+// adapter has to produce that same XML from the JSON for the signature to
+// hold. A nil signatureService neither signs nor verifies.
+type signatureService struct {
+	signer  crypto.Signer
+	certs   [][]byte
+	trusted map[string][]*x509.Certificate
+}
+
+func newSignatureService(cfg SignatureConfig) (*signatureService, error) {
+	s := &signatureService{trusted: make(map[string][]*x509.Certificate)}
+
+	if cfg.Keystore != "" {
+		key, certs, err := loadKeystore(cfg.Keystore, cfg.Password)
+		if err != nil {
+			return nil, fmt.Errorf("keystore %s: %v", cfg.Keystore, err)
+		}
+		s.signer, s.certs = key, certs
+	}
+
+	for bic, path := range cfg.Trusted {
+		certs, err := loadCertificates(path)
+		if err != nil {
+			return nil, fmt.Errorf("trusted certificates of %s: %v", bic, err)
+		}
+		s.trusted[bic] = certs
+	}
+	return s, nil
+}
+
+// loadKeystore reads the RSA key and certificate chain of a PKCS#12 file
+// (.p12, .pfx) or of PEM blocks
+func loadKeystore(path string, password string) (crypto.Signer, [][]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".p12", ".pfx":
+		key, cert, err := pkcs12.Decode(data, password)
+		if err != nil {
+			return nil, nil, err
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, nil, errors.New("key is not RSA")
+		}
+		return rsaKey, [][]byte{cert.Raw}, nil
+	}
+
+	var key *rsa.PrivateKey
+	var certs [][]byte
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			certs = append(certs, block.Bytes)
+		case "RSA PRIVATE KEY":
+			if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+				return nil, nil, err
+			}
+		case "PRIVATE KEY":
+			k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			var ok bool
+			if key, ok = k.(*rsa.PrivateKey); !ok {
+				return nil, nil, errors.New("key is not RSA")
+			}
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, nil, errors.New("encrypted PEM keys are not supported, use PKCS#12")
+		}
+	}
+
+	if key == nil {
+		return nil, nil, errors.New("no private key")
+	}
+	if len(certs) == 0 {
+		return nil, nil, errors.New("no certificate")
+	}
+	return key, certs, nil
+}
+
+func loadCertificates(path string) ([]*x509.Certificate, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate in %s", path)
+	}
+	return certs, nil
+}
+
+// sign puts the signature of msg into AppHdr.Sgntr, replacing any there is
+func (s *signatureService) sign(msg *BusMsg) error {
+	if s == nil || s.signer == nil {
+		return nil
+	}
+
+	// the signature is taken with an empty Sgntr, which is what the
+	// enveloped-signature transform leaves when verifying
+	msg.AppHdr.Sgntr = &SignatureEnvelope{}
+	root, err := busMsgElement(*msg)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := dsig.NewSigningContext(s.signer, s.certs)
+	if err != nil {
+		return err
+	}
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	if err := ctx.SetSignatureMethod(dsig.RSASHA256SignatureMethod); err != nil {
+		return err
+	}
+
+	sig, err := ctx.ConstructSignature(root, true)
+	if err != nil {
+		return fmt.Errorf("sign: %v", err)
+	}
+
+	doc := etree.NewDocument()
+	doc.SetRoot(sig)
+	item, err := doc.WriteToString()
+	if err != nil {
+		return err
+	}
+	msg.AppHdr.Sgntr = &SignatureEnvelope{Item: item}
+	return nil
+}
+
+// verify checks the signature of msg against the certificates trusted for
+// the sender, it returns a *signatureError when it does not hold
+func (s *signatureService) verify(msg BusMsg) error {
+	if s == nil || len(s.trusted) == 0 {
+		return nil
+	}
+
+	if msg.AppHdr.Sgntr == nil || strings.TrimSpace(msg.AppHdr.Sgntr.Item) == "" {
+		return &signatureError{reasonSignatureMissing, errors.New("message is not signed")}
+	}
+
+	var from string
+	if msg.AppHdr.Fr.FIId != nil {
+		from = string(msg.AppHdr.Fr.FIId.FinInstnId.BICFI)
+	}
+	certs, ok := s.trusted[from]
+	if !ok {
+		return &signatureError{reasonSignatureInvalid, fmt.Errorf("no certificate trusted for %q", from)}
+	}
+
+	root, err := busMsgElement(msg)
+	if err != nil {
+		return &signatureError{reasonSignatureInvalid, err}
+	}
+
+	ctx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: certs})
+	if _, err := ctx.Validate(root); err != nil {
+		return &signatureError{reasonSignatureInvalid, err}
+	}
+	return nil
+}
+
+// busMsgElement is the XML tree the signature of msg covers
+func busMsgElement(msg BusMsg) (*etree.Element, error) {
+	data, err := xml.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	return doc.Root(), nil
+}
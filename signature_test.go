@@ -0,0 +1,156 @@
+package main
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// the keystores in testdata hold the same RSA key and self-signed
+// certificate, signature.crt, the PKCS#12 one with password "secret"
+func testSignatures(t *testing.T, keystore, password string) *signatureService {
+	t.Helper()
+	crt := filepath.Join("testdata", "signature.crt")
+	s, err := newSignatureService(SignatureConfig{
+		Keystore: filepath.Join("testdata", keystore),
+		Password: password,
+		Trusted:  map[string]string{"INDOIDJA": crt, "CENAIDJA": crt, "BIFAIDJA": crt},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestLoadKeystore(t *testing.T) {
+	tests := []struct {
+		name     string
+		keystore string
+		password string
+		wantErr  string
+	}{
+		{"PEM", "signature.pem", "", ""},
+		{"PKCS#12", "signature.p12", "secret", ""},
+		{"PKCS#12 wrong password", "signature.p12", "wrong", "password"},
+		{"certificate only", "signature.crt", "", "no private key"},
+		{"missing", "none.pem", "", "no such file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, certs, err := loadKeystore(filepath.Join("testdata", tt.keystore), tt.password)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(certs) != 1 {
+				t.Fatalf("got %d certificates, want 1", len(certs))
+			}
+			cert, err := x509.ParseCertificate(certs[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !key.Public().(*rsa.PublicKey).Equal(cert.PublicKey) {
+				t.Error("key does not belong to the certificate")
+			}
+		})
+	}
+}
+
+// a signature holds on the message as signed and after it went through the
+// JSON of the bus or the XML of BI-FAST
+func TestSignVerify(t *testing.T) {
+	for _, keystore := range []struct{ name, password string }{{"signature.pem", ""}, {"signature.p12", "secret"}} {
+		s := testSignatures(t, keystore.name, keystore.password)
+		for k, msg := range goldenMessages(t) {
+			t.Run(keystore.name+"/"+string(k), func(t *testing.T) {
+				if err := s.sign(&msg); err != nil {
+					t.Fatal(err)
+				}
+				if msg.AppHdr.Sgntr == nil || !strings.Contains(msg.AppHdr.Sgntr.Item, "SignatureValue") {
+					t.Fatalf("Sgntr = %+v, want a signature", msg.AppHdr.Sgntr)
+				}
+				if err := s.verify(msg); err != nil {
+					t.Errorf("as signed: %v", err)
+				}
+
+				data, err := json.Marshal(msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				fromJSON, err := parseBusMsgJSON(data)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := s.verify(fromJSON); err != nil {
+					t.Errorf("after JSON: %v", err)
+				}
+
+				data, err = marshalBusMsgXML(msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				fromXML, err := parseBusMsgXML(data)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := s.verify(fromXML); err != nil {
+					t.Errorf("after XML: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	s := testSignatures(t, "signature.pem", "")
+	tests := []struct {
+		name   string
+		change func(*BusMsg)
+		trust  map[string]string // instead of the certificate for every BIC
+		reason string
+	}{
+		{"not signed", func(msg *BusMsg) { msg.AppHdr.Sgntr = nil }, nil, reasonSignatureMissing},
+		{"empty signature", func(msg *BusMsg) { msg.AppHdr.Sgntr.Item = " " }, nil, reasonSignatureMissing},
+		{"tampered element", func(msg *BusMsg) {
+			msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf[0].PmtId.EndToEndId += "X"
+		}, nil, reasonSignatureInvalid},
+		{"garbled signature", func(msg *BusMsg) {
+			msg.AppHdr.Sgntr.Item = strings.Replace(msg.AppHdr.Sgntr.Item, "SignatureValue>", "SignatureValue>AAAA", 1)
+		}, nil, reasonSignatureInvalid},
+		{"sender not trusted", nil, map[string]string{"BANKIDJA": filepath.Join("testdata", "signature.crt")}, reasonSignatureInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := goldenMessages(t)[kindPacs008]
+			if err := s.sign(&msg); err != nil {
+				t.Fatal(err)
+			}
+			if tt.change != nil {
+				tt.change(&msg)
+			}
+			verifier := s
+			if tt.trust != nil {
+				var err error
+				if verifier, err = newSignatureService(SignatureConfig{Trusted: tt.trust}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := verifier.verify(msg)
+			sigErr, ok := err.(*signatureError)
+			if !ok || sigErr.Reason != tt.reason {
+				t.Errorf("err = %v, want reason %s", err, tt.reason)
+			}
+		})
+	}
+}
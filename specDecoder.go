@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// specAliases are keys channel clients were given before the spelling was
+// fixed, to the key of jsonSpec.go they stand for
+var specAliases = map[string]string{
+	"remmitanceInformationunstructured": "remittanceInformationUnstructured",
+	"CustomerAccountNumbera":            "CustomerAccountNumber",
+}
+
+// foldKey is the form keys are compared in: case does not matter, an alias
+// counts as the key it stands for
+func foldKey(key string) string {
+	folded := strings.ToLower(key)
+	for alias, canonical := range specAliases {
+		if strings.ToLower(alias) == folded {
+			return strings.ToLower(canonical)
+		}
+	}
+	return folded
+}
+
+// canonicalRequest rewrites the keys of the JSON object payload into those
+// of the flat spec v points to, e.g. EndToEndId and endtoendid both become
+// endToEndId for PACS008CreditTransfer. A key given twice in different case
+// or spelling fails. An unknown key is dropped, in strict mode it fails.
+// msgType is not part of any spec and always dropped.
+func canonicalRequest(payload []byte, v interface{}, strict bool) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("request is not a JSON object: %v", err)
+	}
+	specKeys := jsonKeys(v)
+
+	// sorted, so the error for a request is the same every time
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	canonical := make(map[string]json.RawMessage, len(fields))
+	given := make(map[string]string, len(fields))
+	var unknown []string
+	for _, key := range keys {
+		spec, ok := specKeys[foldKey(key)]
+		if !ok {
+			if foldKey(key) != strings.ToLower(msgTypeHeader) {
+				unknown = append(unknown, key)
+			}
+			continue
+		}
+		if prev, ok := given[spec]; ok {
+			return nil, fmt.Errorf("%s given twice, as %s and %s", spec, prev, key)
+		}
+		given[spec] = key
+		canonical[spec] = fields[key]
+	}
+	if strict && len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown keys %s", strings.Join(unknown, ", "))
+	}
+	return json.Marshal(canonical)
+}
+
+// decodeFlatSpec decodes payload into the flat spec v points to, see
+// canonicalRequest
+func decodeFlatSpec(payload []byte, v interface{}, strict bool) error {
+	data, err := canonicalRequest(payload, v, strict)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// jsonKeys maps the folded key of every field of the struct v points to to
+// the key in its json tag
+func jsonKeys(v interface{}) map[string]string {
+	t := reflect.TypeOf(v).Elem()
+	keys := make(map[string]string, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		keys[strings.ToLower(name)] = name
+	}
+	return keys
+}
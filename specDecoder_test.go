@@ -0,0 +1,120 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// the legacy fixtures are the samples as channel clients got them before
+// the spelling was fixed
+func TestDecodeLegacySpelling(t *testing.T) {
+	var proxy, legacyProxy PACS008CTwProxy
+	if err := decodeFlatSpec(readSample(t, "PACS008CTwProxy"), &proxy, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := decodeFlatSpec(readTestdata(t, "PACS008CTwProxyLegacy.json"), &legacyProxy, true); err != nil {
+		t.Fatal(err)
+	}
+	if legacyProxy != proxy || proxy.Remittanceinformationunstructured == "" {
+		t.Errorf("legacy decodes to %+v, want %+v", legacyProxy, proxy)
+	}
+
+	var enq, legacyEnq PACS008AccEnq
+	if err := decodeFlatSpec(readSample(t, "PACS008AccEnq"), &enq, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := decodeFlatSpec(readTestdata(t, "PACS008AccEnqLegacy.json"), &legacyEnq, true); err != nil {
+		t.Fatal(err)
+	}
+	if legacyEnq != enq || enq.Customeraccountnumber == "" {
+		t.Errorf("legacy decodes to %+v, want %+v", legacyEnq, enq)
+	}
+
+	for _, name := range []string{"PACS008CTwProxyLegacy.json", "PACS008AccEnqLegacy.json"} {
+		kind, _, err := dispatchRequest(readTestdata(t, name))
+		if want := requestKind(strings.TrimSuffix(name, "Legacy.json")); err != nil || kind != want {
+			t.Errorf("%s dispatched as %s, %v, want %s", name, kind, err, want)
+		}
+	}
+}
+
+func TestCanonicalRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		strict  bool
+		want    string
+		wantErr string
+	}{
+		{"canonical", `{"endToEndId":"E","currencyCode":"IDR"}`, true, `{"currencyCode":"IDR","endToEndId":"E"}`, ""},
+		{"case variants", `{"EndToEndID":"E","CURRENCYCODE":"IDR"}`, true, `{"currencyCode":"IDR","endToEndId":"E"}`, ""},
+		{"alias", `{"remmitanceInformationunstructured":"R"}`, true, `{"remittanceInformationUnstructured":"R"}`, ""},
+		{"msgType", `{"msgType":"PACS008CreditTransfer","endToEndId":"E"}`, true, `{"endToEndId":"E"}`, ""},
+		{"unknown dropped", `{"endToEndId":"E","extra":1}`, false, `{"endToEndId":"E"}`, ""},
+		{"unknown strict", `{"endToEndId":"E","extra":1,"more":2}`, true, "", "unknown keys extra, more"},
+		{"twice", `{"endToEndId":"E","EndToEndID":"F"}`, false, "", "endToEndId given twice"},
+		{"alias and key", `{"remmitanceInformationunstructured":"R","remittanceInformationUnstructured":"S"}`, false, "", "given twice"},
+		{"not an object", `["endToEndId"]`, false, "", "not a JSON object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := canonicalRequest([]byte(tt.payload), &PACS008CreditTransfer{}, tt.strict)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// without msgType the kind comes from the keys, a proxy key selects the
+// proxy spec even when left empty
+func TestDetectRequestKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    requestKind
+	}{
+		{"account enquiry", `{"customerAccountNumber":"1","creditorAccountId":"2"}`, kindAccountEnquiry},
+		{"FI credit transfer", `{"debtorInstitutionId":"BANKIDJA","creditorAccountId":"2"}`, kindFICreditTransfer},
+		{"return", `{"originalEndToEndId":"E","creditorAccountId":"2"}`, kindCreditTransferReturn},
+		{"proxy", `{"proxyCreditorAccountId":"62812","creditorAccountId":"2"}`, kindCreditTransferProxy},
+		{"empty proxy", `{"proxyCreditorAccountId":"","creditorAccountId":"2"}`, kindCreditTransferProxy},
+		{"folded proxy", `{"ProxyCreditorAccountID":""}`, kindCreditTransferProxy},
+		{"credit transfer", `{"creditorAccountId":"2"}`, kindCreditTransfer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, _, err := dispatchRequest([]byte(tt.payload))
+			if err != nil || kind != tt.want {
+				t.Errorf("dispatched as %s, %v, want %s", kind, err, tt.want)
+			}
+		})
+	}
+
+	_, _, err := dispatchRequest([]byte(`{"endToEndId":"E"}`))
+	if re, ok := err.(*requestError); !ok || re.Err != errUnrecognizedRequest {
+		t.Errorf("err = %v, want %v", err, errUnrecognizedRequest)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// msgTypeHeader of the pacs.028 asking for the status of a credit transfer
+const statusInquiryMsgType = "PACS028StatusInquiry"
+
+// statusInquiry builds the pacs.028 asking BI-FAST for the status of every
+// transaction of orig, a credit transfer (pacs.008 or pacs.009) we sent.
+// BizMsgIdr, MsgId and CreDt are left to appHdrBuilder.complete.
+func statusInquiry(orig BusMsg) (BusMsg, error) {
+	grpHdr, txs, err := transferTransactions(orig)
+	if err != nil {
+		return BusMsg{}, fmt.Errorf("status inquiry: %v", err)
+	}
+
+	orgnlCreDtTm := grpHdr.CreDtTm
+	grpInf := &OriginalGroupInformation29{
+		OrgnlMsgId:   grpHdr.MsgId,
+		OrgnlMsgNmId: Max35Text(orig.Document.Kind()),
+		OrgnlCreDtTm: &orgnlCreDtTm,
+	}
+
+	req := &FIToFIPaymentStatusRequestV04{}
+	for _, tx := range txs {
+		req.TxInf = append(req.TxInf, PaymentTransaction121{
+			OrgnlGrpInf:     grpInf,
+			OrgnlEndToEndId: tx.endToEndId,
+			OrgnlTxId:       tx.txId,
+		})
+	}
+
+	msg := BusMsg{
+		AppHdr: BusinessApplicationHeaderV01{
+			Fr:        orig.AppHdr.Fr,
+			To:        biFastParty(biFastHubBIC),
+			MsgDefIdr: pacs028MsgDefIdr,
+			BizSvc:    biFastBizSvc,
+		},
+	}
+	msg.Document.FIToFIPmtStsReq = req
+	return msg, nil
+}
+
+// encodeStatusInquiry is the JSON of the finished pacs.028 for orig
+func encodeStatusInquiry(orig BusMsg) ([]byte, error) {
+	msg, err := statusInquiry(orig)
+	if err != nil {
+		return nil, err
+	}
+	_, txs, _ := transferTransactions(orig)
+	if err := finishMessage(&msg, transactionType(txs)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(msg)
+}
+
+// inquireStatus asks for the status of orig, the credit transfer of head
+// that got no response in time, with one pacs.028 per wait of
+// `config.StatusInquiry`. The inquiries go out under head, so the late
+// response to orig resolves the wait as well as a pacs.002 answering an
+// inquiry. A pending (PDNG) answer is followed by the next inquiry, and
+// returned when it stays the last one. errResponseTimeout means no inquiry
+// was answered.
+func inquireStatus(connCtx context.Context, head string, orig BusMsg) (resConsume, error) {
+	var last *resConsume
+	for i, wait := range config.StatusInquiry {
+		value, err := encodeStatusInquiry(orig)
+		if err != nil {
+			return resConsume{}, err
+		}
+
+		pending, err := registry.register(head)
+		if err != nil {
+			return resConsume{}, err
+		}
+		data := busMessage{
+			Topic:   config.Kafka.RequestTopic,
+			Value:   value,
+			Headers: map[string]string{correlationHeader: head, msgTypeHeader: statusInquiryMsgType},
+		}
+		if err := bus.Publish(data, deliveryReport(head)); err != nil {
+			pending.cancel()
+			return resConsume{}, err
+		}
+		log.Printf("Status inquiry %d of %s is produced to Kafka\n", i+1, head)
+
+		ctx, cancel := context.WithTimeout(connCtx, time.Duration(wait))
+		res, err := pending.wait(ctx)
+		cancel()
+		if err == errResponseTimeout {
+			continue
+		}
+		if err != nil {
+			return resConsume{}, err
+		}
+		if !isPendingStatus(res.Content) {
+			return res, nil
+		}
+		last = &res
+	}
+	if last != nil {
+		return *last, nil
+	}
+	return resConsume{}, errResponseTimeout
+}
+
+// isPendingStatus tells whether content is a pacs.002 reporting PDNG
+func isPendingStatus(content string) bool {
+	msg, err := parseBusMsgJSON([]byte(content))
+	if err != nil || msg.Document.Kind() != kindPacs002 {
+		return false
+	}
+	txs := msg.Document.FIToFIPmtStsRpt.TxInfAndSts
+	return len(txs) > 0 && txs[0].TxSts == "PDNG"
+}
+
+// pendingStatusResponse is what the channel gets for orig when no status
+// inquiry was answered either, the outcome is left to reconciliation
+func pendingStatusResponse(orig BusMsg) ([]byte, error) {
+	res := PACS002StatusResponse{
+		Status:     "PDNG",
+		Reasontext: "no status from BI-FAST",
+	}
+	if grpHdr, txs, err := transferTransactions(orig); err == nil {
+		res.Endtoendid = string(txs[0].endToEndId)
+		res.Transactionid = string(txs[0].txId)
+		res.Originalmessageid = string(grpHdr.MsgId)
+	}
+	return json.Marshal(res)
+}
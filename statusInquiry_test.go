@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func withStatusInquiry(waits ...time.Duration) gatewayOption {
+	return func() {
+		config.StatusInquiry = nil
+		for _, d := range waits {
+			config.StatusInquiry = append(config.StatusInquiry, duration(d))
+		}
+	}
+}
+
+// failingInquiry is a bus on which status inquiries do not arrive
+type failingInquiry struct {
+	*memoryBus
+}
+
+func (b failingInquiry) Publish(msg busMessage, delivered func(error)) error {
+	if msg.Headers[msgTypeHeader] != statusInquiryMsgType {
+		return b.memoryBus.Publish(msg, delivered)
+	}
+	if delivered != nil {
+		delivered(errors.New("broker down"))
+	}
+	return nil
+}
+
+func withFailingInquiry() {
+	bus = failingInquiry{bus.(*memoryBus)}
+}
+
+// inquiredAdapter never answers a credit transfer and answers its n-th status
+// inquiry with a pacs.002 of status answers[n], "" answering nothing
+type inquiredAdapter struct {
+	answers []string
+
+	mu        sync.Mutex
+	orig      BusMsg
+	inquiries int
+}
+
+func (a *inquiredAdapter) respond(req busMessage) []busMessage {
+	msg, err := parseBusMsgJSON(req.Value)
+	if err != nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	switch msg.Document.Kind() {
+	case kindPacs008:
+		a.orig = msg
+		return nil
+	case kindPacs028:
+		a.inquiries++
+	default:
+		return nil
+	}
+	if a.inquiries > len(a.answers) || a.answers[a.inquiries-1] == "" {
+		return nil
+	}
+
+	// statusReport builds final statuses only, PDNG is set afterwards
+	status := a.answers[a.inquiries-1]
+	rpt, err := statusReport(a.orig, "ACSC", "")
+	if err != nil {
+		return nil
+	}
+	rpt.Document.FIToFIPmtStsRpt.TxInfAndSts[0].TxSts = ExternalPaymentTransactionStatus1Code(status)
+	if status == "RJCT" {
+		rpt.Document.FIToFIPmtStsRpt.TxInfAndSts[0].StsRsnInf = []StatusReasonInformation12{
+			{Rsn: &StatusReason6Choice{Cd: "AC03"}},
+		}
+	}
+	if err := headers.complete(&rpt, creditTransferTxType); err != nil {
+		return nil
+	}
+	value, err := json.Marshal(rpt)
+	if err != nil {
+		return nil
+	}
+	return []busMessage{{Topic: config.Kafka.ResponseTopic, Value: value, Headers: req.Headers}}
+}
+
+// a credit transfer without response in time has its status inquired, the
+// channel gets the status found, or PDNG when there is none
+func TestStatusInquiry(t *testing.T) {
+	const wait = 150 * time.Millisecond
+	tests := []struct {
+		name      string
+		answers   []string
+		breakBus  gatewayOption
+		status    string
+		reason    string
+		inquiries int
+	}{
+		{"answered", []string{"ACSC"}, nil, "ACSC", "", 1},
+		{"rejected", []string{"RJCT"}, nil, "RJCT", "AC03", 1},
+		{"answered after pending", []string{"PDNG", "ACSC"}, nil, "ACSC", "", 2},
+		{"answered after unanswered", []string{"", "", "ACSC"}, nil, "ACSC", "", 3},
+		{"pending to the last", []string{"PDNG", "PDNG", "PDNG"}, nil, "PDNG", "", 3},
+		{"unanswered", nil, nil, "PDNG", "", 3},
+		{"inquiry fails", []string{"ACSC"}, withFailingInquiry, "PDNG", reasonNoResponse, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := &inquiredAdapter{answers: tt.answers}
+			opts := []gatewayOption{withResponseTimeout(wait), withStatusInquiry(wait, wait, wait)}
+			if tt.breakBus != nil {
+				opts = append(opts, tt.breakBus)
+			}
+			addr := startGateway(t, adapter.respond, opts...)
+
+			var res PACS002StatusResponse
+			reply := exchange(t, addr, readSample(t, "PACS008CreditTransfer"))
+			if err := json.Unmarshal(reply, &res); err != nil {
+				t.Fatalf("reply %s: %v", reply, err)
+			}
+			if res.Status != tt.status || res.Reasoncode != tt.reason {
+				t.Errorf("reply = %s, want %s %s", reply, tt.status, tt.reason)
+			}
+			if tt.breakBus == nil && res.Endtoendid != "20210301INDOIDJA010ORB12345678" {
+				t.Errorf("reply = %s, want it for the sample EndToEndId", reply)
+			}
+
+			adapter.mu.Lock()
+			defer adapter.mu.Unlock()
+			if adapter.inquiries != tt.inquiries {
+				t.Errorf("%d status inquiries, want %d", adapter.inquiries, tt.inquiries)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// statuses we report as creditor agent for a credit transfer
+var reportStatuses = map[string]bool{
+	"ACSC": true,
+	"ACTC": true,
+	"RJCT": true,
+}
+
+const (
+	reasonInvalidFormat = "FF01"
+
+	// msgTypeHeader of the pacs.002 we publish, it answers no channel request
+	statusReportMsgType = "PACS002StatusReport"
+
+	// msgTypeHeader of a credit transfer forwarded to core banking
+	inboundCreditTransferMsgType = "InboundCreditTransfer"
+)
+
+// statusReport builds the pacs.002 answering orig, a credit transfer (pacs.008
+// or pacs.009) BI-FAST sends to us as creditor. Every transaction of orig
+// gets status, and reason as StsRsnInf when given, RJCT needs one. BizMsgIdr,
+// MsgId and CreDt are left to appHdrBuilder.complete.
+func statusReport(orig BusMsg, status string, reason string) (BusMsg, error) {
+	if !reportStatuses[status] {
+		return BusMsg{}, fmt.Errorf("status report with TxSts %q, expected ACSC, ACTC or RJCT", status)
+	}
+	if status == "RJCT" && reason == "" {
+		return BusMsg{}, errors.New("status report rejects without reason")
+	}
+
+	grpHdr, txs, err := transferTransactions(orig)
+	if err != nil {
+		return BusMsg{}, fmt.Errorf("status report: %v", err)
+	}
+	creditor := txs[0].creditor
+	if creditor == "" {
+		return BusMsg{}, errors.New("status report for credit transfer without creditor BICFI")
+	}
+
+	orgnlCreDtTm := grpHdr.CreDtTm
+	grpInf := &OriginalGroupInformation29{
+		OrgnlMsgId:   grpHdr.MsgId,
+		OrgnlMsgNmId: Max35Text(orig.Document.Kind()),
+		OrgnlCreDtTm: &orgnlCreDtTm,
+	}
+
+	rpt := &FIToFIPaymentStatusReportV10{}
+	for _, tx := range txs {
+		sts := PaymentTransaction110{
+			OrgnlGrpInf:     grpInf,
+			OrgnlEndToEndId: tx.endToEndId,
+			OrgnlTxId:       tx.txId,
+			TxSts:           ExternalPaymentTransactionStatus1Code(status),
+			OrgnlTxRef:      tx.ref,
+		}
+		if reason != "" {
+			sts.StsRsnInf = []StatusReasonInformation12{{
+				Rsn: &StatusReason6Choice{Cd: ExternalStatusReason1Code(reason)},
+			}}
+		}
+		rpt.TxInfAndSts = append(rpt.TxInfAndSts, sts)
+	}
+
+	msg := BusMsg{
+		AppHdr: BusinessApplicationHeaderV01{
+			Fr:        biFastParty(creditor),
+			To:        biFastParty(biFastHubBIC),
+			MsgDefIdr: pacs002MsgDefIdr,
+			BizSvc:    biFastBizSvc,
+		},
+	}
+	msg.Document.FIToFIPmtStsRpt = rpt
+	return msg, nil
+}
+
+// transferTransaction is what status reports and inquiries refer to in a
+// transaction of a pacs.008 or pacs.009
+type transferTransaction struct {
+	endToEndId Max35Text
+	txId       Max35Text
+	pmtTpInf   *PaymentTypeInformation28
+	creditor   string // BIC of the creditor agent, of the creditor in a pacs.009
+	ref        *OriginalTransactionReference28
+}
+
+// transferTransactions reads the group header and the transactions of msg,
+// a pacs.008 or pacs.009 with at least one transaction
+func transferTransactions(msg BusMsg) (GroupHeader93, []transferTransaction, error) {
+	var grpHdr GroupHeader93
+	var txs []transferTransaction
+	switch k := msg.Document.Kind(); k {
+	case kindPacs008:
+		ct := msg.Document.FIToFICstmrCdtTrf
+		grpHdr = ct.GrpHdr
+		for _, tx := range ct.CdtTrfTxInf {
+			txs = append(txs, transferTransaction{
+				endToEndId: tx.PmtId.EndToEndId,
+				txId:       tx.PmtId.TxId,
+				pmtTpInf:   tx.PmtTpInf,
+				creditor:   string(tx.CdtrAgt.FinInstnId.BICFI),
+				ref:        originalTransactionReference(tx),
+			})
+		}
+	case kindPacs009:
+		ct := msg.Document.FICdtTrf
+		grpHdr = ct.GrpHdr
+		for _, tx := range ct.CdtTrfTxInf {
+			txs = append(txs, transferTransaction{
+				endToEndId: tx.PmtId.EndToEndId,
+				txId:       tx.PmtId.TxId,
+				pmtTpInf:   tx.PmtTpInf,
+				creditor:   string(tx.Cdtr.FinInstnId.BICFI),
+				ref:        fiTransactionReference(tx),
+			})
+		}
+	default:
+		return GroupHeader93{}, nil, fmt.Errorf("%s is no credit transfer", k)
+	}
+	if len(txs) == 0 {
+		return GroupHeader93{}, nil, errors.New("credit transfer without CdtTrfTxInf")
+	}
+	return grpHdr, txs, nil
+}
+
+// originalTransactionReference copies what identifies the credited
+// transaction: amount, parties, their accounts and agents
+func originalTransactionReference(tx CreditTransferTransaction39) *OriginalTransactionReference28 {
+	dbtr, cdtr := tx.Dbtr, tx.Cdtr
+	dbtrAgt, cdtrAgt := tx.DbtrAgt, tx.CdtrAgt
+	return &OriginalTransactionReference28{
+		IntrBkSttlmAmt: &ActiveOrHistoricCurrencyAndAmount{
+			Value: tx.IntrBkSttlmAmt.Value,
+			Ccy:   ActiveOrHistoricCurrencyCode(tx.IntrBkSttlmAmt.Ccy),
+		},
+		RmtInf:   tx.RmtInf,
+		Dbtr:     &Party40Choice{Pty: &dbtr},
+		DbtrAcct: tx.DbtrAcct,
+		DbtrAgt:  &dbtrAgt,
+		CdtrAgt:  &cdtrAgt,
+		Cdtr:     &Party40Choice{Pty: &cdtr},
+		CdtrAcct: tx.CdtrAcct,
+	}
+}
+
+// fiTransactionReference is originalTransactionReference of a pacs.009,
+// debtor and creditor are the institutions themselves
+func fiTransactionReference(tx CreditTransferTransaction44) *OriginalTransactionReference28 {
+	dbtr, cdtr := tx.Dbtr, tx.Cdtr
+	return &OriginalTransactionReference28{
+		IntrBkSttlmAmt: &ActiveOrHistoricCurrencyAndAmount{
+			Value: tx.IntrBkSttlmAmt.Value,
+			Ccy:   ActiveOrHistoricCurrencyCode(tx.IntrBkSttlmAmt.Ccy),
+		},
+		IntrBkSttlmDt: tx.IntrBkSttlmDt,
+		Dbtr:          &Party40Choice{Agt: &dbtr},
+		DbtrAcct:      tx.DbtrAcct,
+		Cdtr:          &Party40Choice{Agt: &cdtr},
+		CdtrAcct:      tx.CdtrAcct,
+	}
+}
+
+// transactionType is the BI-FAST transaction type of a credit transfer, the
+// start of its CtgyPurp
+func transactionType(txs []transferTransaction) string {
+	for _, tx := range txs {
+		if tx.pmtTpInf != nil && tx.pmtTpInf.CtgyPurp != nil && len(tx.pmtTpInf.CtgyPurp.Prtry) >= 3 {
+			return string(tx.pmtTpInf.CtgyPurp.Prtry[:3])
+		}
+	}
+	return creditTransferTxType
+}
+
+// encodeStatusReport is the JSON of the finished pacs.002 answering orig
+func encodeStatusReport(orig BusMsg, status string, reason string) ([]byte, error) {
+	msg, err := statusReport(orig, status, reason)
+	if err != nil {
+		return nil, err
+	}
+	_, txs, _ := transferTransactions(orig)
+	if err := finishMessage(&msg, transactionType(txs)); err != nil {
+		return nil, err
+	}
+	return json.Marshal(msg)
+}
+
+// rejectReason is why the gateway itself rejects an inbound credit transfer:
+// unsigned, wrongly signed or breaking the ISO 20022 rules. Whether it is
+// settled is up to core banking, "" lets it decide.
+func rejectReason(msg BusMsg) string {
+	if err := signatures.verify(msg); err != nil {
+		log.Printf("Rejecting credit transfer: %v\n", err)
+		return signatureReason(err)
+	}
+	if err := msg.Validate(); err != nil {
+		log.Printf("Rejecting credit transfer: %v\n", err)
+		return reasonInvalidFormat
+	}
+	return ""
+}
+
+// answerCreditTransfer answers value with a pacs.002 on the request topic
+// when it is a credit transfer sent to us as creditor. It reports whether
+// value was one.
+func answerCreditTransfer(value []byte) bool {
+	msg, err := parseBusMsgJSON(value)
+	if err != nil || isAccountEnquiry(msg) {
+		return false
+	}
+	grpHdr, txs, err := transferTransactions(msg)
+	if err != nil {
+		return false
+	}
+
+	var endToEndIds []string
+	for _, tx := range txs {
+		endToEndIds = append(endToEndIds, string(tx.endToEndId))
+	}
+	log.Printf("New credit transfer %s (%s) from BI-FAST\n", grpHdr.MsgId, strings.Join(endToEndIds, ", "))
+
+	if reason := rejectReason(msg); reason != "" {
+		publishStatusReport(msg, "RJCT", reason)
+		return true
+	}
+	// the decision of core banking is not awaited on the consumer
+	settlements.Add(1)
+	go func() {
+		defer settlements.Done()
+		settleCreditTransfer(msg, value)
+	}()
+	return true
+}
+
+// settleCreditTransfer forwards msg, received as value, to core banking and
+// reports its decision. Without decision in time no status report is sent,
+// BI-FAST times the transfer out itself.
+func settleCreditTransfer(msg BusMsg, value []byte) {
+	grpHdr, _, _ := transferTransactions(msg)
+	head := idGenerator.next().id
+
+	pending, err := registry.register(head)
+	if err != nil {
+		log.Printf("Fail to forward credit transfer %s: %v\n", grpHdr.MsgId, err)
+		return
+	}
+	data := busMessage{
+		Topic:   config.Kafka.CoreBankingTopic,
+		Value:   value,
+		Headers: map[string]string{correlationHeader: head, msgTypeHeader: inboundCreditTransferMsgType},
+	}
+	if err := bus.Publish(data, deliveryReport(head)); err != nil {
+		log.Printf("Fail to forward credit transfer %s: %v\n", grpHdr.MsgId, err)
+		pending.cancel()
+		return
+	}
+	log.Printf("Credit transfer %s is produced to %s as %s\n", grpHdr.MsgId, data.Topic, head)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(config.ResponseTimeout))
+	defer cancel()
+	res, err := pending.wait(ctx)
+	if err != nil {
+		log.Printf("No decision of core banking on %s: %v\n", grpHdr.MsgId, err)
+		return
+	}
+	status, reason, err := coreBankingDecision([]byte(res.Content))
+	if err != nil {
+		log.Printf("Invalid decision of core banking on %s: %v\n", grpHdr.MsgId, err)
+		return
+	}
+
+	if status == "ACSC" && msg.Document.FIToFICstmrCdtTrf != nil {
+		// kept for the returns of it we may send later
+		if err := transactions.received(msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf); err != nil {
+			log.Printf("Fail to keep credit transfer %s: %v\n", grpHdr.MsgId, err)
+		}
+	}
+	publishStatusReport(msg, status, reason)
+}
+
+// coreBankingDecision reads the answer of core banking to an inbound credit
+// transfer, a PACS002StatusResponse settling it (ACSC) or rejecting it with
+// a reason such as AC03 or AC04
+func coreBankingDecision(content []byte) (string, string, error) {
+	var res PACS002StatusResponse
+	if err := json.Unmarshal(content, &res); err != nil {
+		return "", "", err
+	}
+	switch {
+	case res.Status == "ACSC":
+		return res.Status, "", nil
+	case res.Status == "RJCT" && res.Reasoncode != "":
+		return res.Status, res.Reasoncode, nil
+	case res.Status == "RJCT":
+		return "", "", errors.New("RJCT without reasonCode")
+	}
+	return "", "", fmt.Errorf("status %q, expected ACSC or RJCT", res.Status)
+}
+
+// publishStatusReport answers orig with a pacs.002 of status and reason
+func publishStatusReport(orig BusMsg, status string, reason string) {
+	grpHdr, _, _ := transferTransactions(orig)
+	report, err := encodeStatusReport(orig, status, reason)
+	if err != nil {
+		log.Printf("Fail to report status of %s: %v\n", grpHdr.MsgId, err)
+		return
+	}
+
+	data := busMessage{
+		Topic:   config.Kafka.RequestTopic,
+		Value:   report,
+		Headers: map[string]string{msgTypeHeader: statusReportMsgType},
+	}
+	err = bus.Publish(data, func(err error) {
+		if err != nil {
+			log.Printf("Fail to deliver status report of %s: %v\n", grpHdr.MsgId, err)
+		}
+	})
+	if err != nil {
+		log.Printf("Fail to produce status report of %s: %v\n", grpHdr.MsgId, err)
+		return
+	}
+	log.Printf("Status report %s of %s is produced to Kafka\n", status, grpHdr.MsgId)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var (
+	errUnknownOriginal = errors.New("original transaction not found")
+	errReturnExceeds   = errors.New("return amount exceeds what is left of the original")
+	errDuplicateReturn = errors.New("a return with this EndToEndId is already recorded")
+)
+
+// transactionStore keeps the credit transfers we received and the returns
+// sent for them, so a return can be checked against its original. Every
+// change is appended to path as a JSON line before it takes effect and
+// replayed by openTransactionStore.
+type transactionStore struct {
+	mu        sync.Mutex
+	file      *os.File
+	originals map[string]CreditTransferTransaction39 // by EndToEndId
+	returns   map[string]map[string]Decimal          // by original, then return EndToEndId
+}
+
+// transactionRecord is a line of the store file, one of its members is set
+type transactionRecord struct {
+	Received *CreditTransferTransaction39 `json:"received,omitempty"`
+	Returned *returnRecord                `json:"returned,omitempty"`
+	Rejected *returnRecord                `json:"rejected,omitempty"` // a return BI-FAST rejected no longer counts
+}
+
+type returnRecord struct {
+	EndToEndId      string  `json:"endToEndId"`
+	OrgnlEndToEndId string  `json:"orgnlEndToEndId"`
+	Amount          Decimal `json:"amount"`
+}
+
+// openTransactionStore replays the store file at path, a missing file starts
+// an empty store
+func openTransactionStore(path string) (*transactionStore, error) {
+	s := &transactionStore{
+		originals: make(map[string]CreditTransferTransaction39),
+		returns:   make(map[string]map[string]Decimal),
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var rec transactionRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("transaction file %s:%d: %v", path, line, err)
+		}
+		s.apply(rec)
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("transaction file %s: %v", path, err)
+	}
+	s.file = f
+	return s, nil
+}
+
+func (s *transactionStore) apply(rec transactionRecord) {
+	switch {
+	case rec.Received != nil:
+		s.originals[string(rec.Received.PmtId.EndToEndId)] = *rec.Received
+	case rec.Returned != nil:
+		r := rec.Returned
+		if s.returns[r.OrgnlEndToEndId] == nil {
+			s.returns[r.OrgnlEndToEndId] = make(map[string]Decimal)
+		}
+		s.returns[r.OrgnlEndToEndId][r.EndToEndId] = r.Amount
+	case rec.Rejected != nil:
+		delete(s.returns[rec.Rejected.OrgnlEndToEndId], rec.Rejected.EndToEndId)
+	}
+}
+
+// write appends rec to the store file and applies it
+func (s *transactionStore) write(rec transactionRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("save transaction: %v", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("save transaction: %v", err)
+	}
+	s.apply(rec)
+	return nil
+}
+
+// received keeps the transactions of a credit transfer we accepted as
+// creditor
+func (s *transactionStore) received(txs []CreditTransferTransaction39) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range txs {
+		if err := s.write(transactionRecord{Received: &txs[i]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// original is the received transaction with endToEndId and the sum of the
+// returns sent for it
+func (s *transactionStore) original(endToEndId string) (CreditTransferTransaction39, Decimal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.returned(endToEndId)
+}
+
+func (s *transactionStore) returned(endToEndId string) (CreditTransferTransaction39, Decimal, error) {
+	orig, ok := s.originals[endToEndId]
+	if !ok {
+		return CreditTransferTransaction39{}, Decimal{}, errUnknownOriginal
+	}
+
+	var returned Decimal
+	for _, amt := range s.returns[endToEndId] {
+		var err error
+		if returned, err = returned.Add(amt); err != nil {
+			return CreditTransferTransaction39{}, Decimal{}, err
+		}
+	}
+	return orig, returned, nil
+}
+
+// checkReturn fails when a return of amount would take more from orig than
+// is left after the returns already sent
+func checkReturn(orig CreditTransferTransaction39, returned Decimal, amount Decimal) error {
+	total, err := returned.Add(amount)
+	if err != nil {
+		return err
+	}
+	if total.Cmp(orig.IntrBkSttlmAmt.Value) > 0 {
+		return fmt.Errorf("%w: %s of %s %s already returned", errReturnExceeds, returned, orig.IntrBkSttlmAmt.Value, orig.IntrBkSttlmAmt.Ccy)
+	}
+	return nil
+}
+
+// addReturn records a return of amount sent for the original
+// orgnlEndToEndId, unless it exceeds what is left of the original or its
+// endToEndId is taken by a return of any original
+func (s *transactionStore) addReturn(endToEndId string, orgnlEndToEndId string, amount Decimal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// a second record under endToEndId would replace the amount of the first
+	for _, rets := range s.returns {
+		if _, ok := rets[endToEndId]; ok {
+			return errDuplicateReturn
+		}
+	}
+
+	orig, returned, err := s.returned(orgnlEndToEndId)
+	if err != nil {
+		return err
+	}
+	if err := checkReturn(orig, returned, amount); err != nil {
+		return err
+	}
+	return s.write(transactionRecord{Returned: &returnRecord{
+		EndToEndId:      endToEndId,
+		OrgnlEndToEndId: orgnlEndToEndId,
+		Amount:          amount,
+	}})
+}
+
+// rejectReturn gives back to its original what the return endToEndId took,
+// BI-FAST did not carry it out
+func (s *transactionStore) rejectReturn(endToEndId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for orig, rets := range s.returns {
+		if amt, ok := rets[endToEndId]; ok {
+			return s.write(transactionRecord{Rejected: &returnRecord{
+				EndToEndId:      endToEndId,
+				OrgnlEndToEndId: orig,
+				Amount:          amt,
+			}})
+		}
+	}
+	return nil
+}
+
+func (s *transactionStore) Close() error {
+	return s.file.Close()
+}
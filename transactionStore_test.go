@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddReturnDuplicateEndToEndId(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "netChannel.tx")
+	store, err := openTransactionStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { store.Close() }()
+
+	hundred, _ := parseDecimal("100.00")
+	for _, id := range []string{"ORIG1", "ORIG2"} {
+		tx := CreditTransferTransaction39{
+			PmtId:          PaymentIdentification7{EndToEndId: Max35Text(id)},
+			IntrBkSttlmAmt: ActiveCurrencyAndAmount{Value: hundred, Ccy: "IDR"},
+		}
+		if err := store.received([]CreditTransferTransaction39{tx}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sixty, _ := parseDecimal("60.00")
+	forty, _ := parseDecimal("40.00")
+	tests := []struct {
+		endToEndId, orig string
+		amount           Decimal
+		wantErr          error
+	}{
+		{"X", "ORIG1", sixty, nil},
+		{"X", "ORIG1", forty, errDuplicateReturn},
+		{"X", "ORIG2", forty, errDuplicateReturn},
+		{"Y", "ORIG1", forty, nil},
+		{"Z", "ORIG1", forty, errReturnExceeds},
+	}
+	for _, tt := range tests {
+		err := store.addReturn(tt.endToEndId, tt.orig, tt.amount)
+		if !errors.Is(err, tt.wantErr) || (err != nil) != (tt.wantErr != nil) {
+			t.Errorf("addReturn(%s, %s, %s) = %v, want %v", tt.endToEndId, tt.orig, tt.amount, err, tt.wantErr)
+		}
+	}
+
+	// the same totals after the store is replayed from its file
+	for i := 0; i < 2; i++ {
+		for orig, want := range map[string]string{"ORIG1": "100.00", "ORIG2": "0"} {
+			if _, returned, err := store.original(orig); err != nil || returned.String() != want {
+				t.Errorf("returned of %s = %s, %v, want %s", orig, returned, err, want)
+			}
+		}
+		store.Close()
+		if store, err = openTransactionStore(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a released return frees its EndToEndId
+	if err := store.rejectReturn("X"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.addReturn("X", "ORIG2", forty); err != nil {
+		t.Errorf("addReturn of released X = %v", err)
+	}
+}
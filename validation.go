@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// simpleTypeRule checks the value of an ISO 20022 simple type, as described
+// by the comment on the type in reqSpec.go. It returns what is wrong, or "".
+type simpleTypeRule func(v string) string
+
+func pattern(expr string) simpleTypeRule {
+	re := regexp.MustCompile("^(?:" + expr + ")$")
+	return func(v string) string {
+		if !re.MatchString(v) {
+			return fmt.Sprintf("%q does not match the pattern %s", v, expr)
+		}
+		return ""
+	}
+}
+
+func maxLength(n int) simpleTypeRule {
+	return func(v string) string {
+		if l := utf8.RuneCountInString(v); l > n {
+			return fmt.Sprintf("%d characters, at most %d allowed", l, n)
+		}
+		return ""
+	}
+}
+
+func oneOf(codes ...string) simpleTypeRule {
+	return func(v string) string {
+		for _, c := range codes {
+			if v == c {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of %s", v, strings.Join(codes, ", "))
+	}
+}
+
+var simpleTypeRules = map[reflect.Type]simpleTypeRule{
+	// patterns
+	reflect.TypeOf(ActiveCurrencyCode("")):           pattern(`[A-Z]{3,3}`),
+	reflect.TypeOf(ActiveOrHistoricCurrencyCode("")): pattern(`[A-Z]{3,3}`),
+	reflect.TypeOf(AnyBICDec2014Identifier("")):      pattern(`[A-Z0-9]{4,4}[A-Z]{2,2}[A-Z0-9]{2,2}([A-Z0-9]{3,3}){0,1}`),
+	reflect.TypeOf(AnyBICIdentifier("")):             pattern(`[A-Z]{6,6}[A-Z2-9][A-NP-Z0-9]([A-Z0-9]{3,3}){0,1}`),
+	reflect.TypeOf(BICFIDec2014Identifier("")):       pattern(`[A-Z0-9]{4,4}[A-Z]{2,2}[A-Z0-9]{2,2}([A-Z0-9]{3,3}){0,1}`),
+	reflect.TypeOf(BICFIIdentifier("")):              pattern(`[A-Z]{6,6}[A-Z2-9][A-NP-Z0-9]([A-Z0-9]{3,3}){0,1}`),
+	reflect.TypeOf(CountryCode("")):                  pattern(`[A-Z]{2,2}`),
+	reflect.TypeOf(Exact2NumericText("")):            pattern(`[0-9]{2}`),
+	reflect.TypeOf(Exact4AlphaNumericText("")):       pattern(`[a-zA-Z0-9]{4}`),
+	reflect.TypeOf(IBAN2007Identifier("")):           pattern(`[A-Z]{2,2}[0-9]{2,2}[a-zA-Z0-9]{1,30}`),
+	reflect.TypeOf(LEIIdentifier("")):                pattern(`[A-Z0-9]{18,18}[0-9]{2,2}`),
+	reflect.TypeOf(Max15NumericText("")):             pattern(`[0-9]{1,15}`),
+	reflect.TypeOf(PhoneNumber("")):                  pattern(`\+[0-9]{1,3}-[0-9()+\-]{1,30}`),
+	reflect.TypeOf(UUIDv4Identifier("")):             pattern(`[a-f0-9]{8}-[a-f0-9]{4}-4[a-f0-9]{3}-[89ab][a-f0-9]{3}-[a-f0-9]{12}`),
+
+	// lengths
+	reflect.TypeOf(ExternalAccountIdentification1Code("")):              maxLength(4),
+	reflect.TypeOf(ExternalCashAccountType1Code("")):                    maxLength(4),
+	reflect.TypeOf(ExternalCashClearingSystem1Code("")):                 maxLength(3),
+	reflect.TypeOf(ExternalCategoryPurpose1Code("")):                    maxLength(4),
+	reflect.TypeOf(ExternalClearingSystemIdentification1Code("")):       maxLength(5),
+	reflect.TypeOf(ExternalCreditorAgentInstruction1Code("")):           maxLength(4),
+	reflect.TypeOf(ExternalDiscountAmountType1Code("")):                 maxLength(4),
+	reflect.TypeOf(ExternalDocumentLineType1Code("")):                   maxLength(4),
+	reflect.TypeOf(ExternalFinancialInstitutionIdentification1Code("")): maxLength(4),
+	reflect.TypeOf(ExternalGarnishmentType1Code("")):                    maxLength(4),
+	reflect.TypeOf(ExternalLocalInstrument1Code("")):                    maxLength(35),
+	reflect.TypeOf(ExternalMandateSetupReason1Code("")):                 maxLength(4),
+	reflect.TypeOf(ExternalOrganisationIdentification1Code("")):         maxLength(4),
+	reflect.TypeOf(ExternalPaymentGroupStatus1Code("")):                 maxLength(4),
+	reflect.TypeOf(ExternalPaymentTransactionStatus1Code("")):           maxLength(4),
+	reflect.TypeOf(ExternalPersonIdentification1Code("")):               maxLength(4),
+	reflect.TypeOf(ExternalProxyAccountType1Code("")):                   maxLength(4),
+	reflect.TypeOf(ExternalPurpose1Code("")):                            maxLength(4),
+	reflect.TypeOf(ExternalServiceLevel1Code("")):                       maxLength(4),
+	reflect.TypeOf(ExternalStatusReason1Code("")):                       maxLength(4),
+	reflect.TypeOf(ExternalTaxAmountType1Code("")):                      maxLength(4),
+	reflect.TypeOf(Max1025Text("")):                                     maxLength(1025),
+	reflect.TypeOf(Max105Text("")):                                      maxLength(105),
+	reflect.TypeOf(Max10Text("")):                                       maxLength(10),
+	reflect.TypeOf(Max128Text("")):                                      maxLength(128),
+	reflect.TypeOf(Max140Text("")):                                      maxLength(140),
+	reflect.TypeOf(Max16Text("")):                                       maxLength(16),
+	reflect.TypeOf(Max2048Text("")):                                     maxLength(2048),
+	reflect.TypeOf(Max34Text("")):                                       maxLength(34),
+	reflect.TypeOf(Max350Text("")):                                      maxLength(350),
+	reflect.TypeOf(Max35Text("")):                                       maxLength(35),
+	reflect.TypeOf(Max4Text("")):                                        maxLength(4),
+	reflect.TypeOf(Max70Text("")):                                       maxLength(70),
+
+	// code sets
+	reflect.TypeOf(AddressType2Code("")):              oneOf("ADDR", "PBOX", "HOME", "BIZZ", "MLTO", "DLVY"),
+	reflect.TypeOf(ChargeBearerType1Code("")):         oneOf("DEBT", "CRED", "SHAR", "SLEV"),
+	reflect.TypeOf(ClearingChannel2Code("")):          oneOf("RTGS", "RTNS", "MPNS", "BOOK"),
+	reflect.TypeOf(CopyDuplicate1Code("")):            oneOf("CODU", "COPY", "DUPL"),
+	reflect.TypeOf(CreditDebitCode("")):               oneOf("CRDT", "DBIT"),
+	reflect.TypeOf(DocumentType3Code("")):             oneOf("RADM", "RPIN", "FXDR", "DISP", "PUOR", "SCOR"),
+	reflect.TypeOf(DocumentType6Code("")):             oneOf("MSIN", "CNFA", "DNFA", "CINV", "CREN", "DEBN", "HIRI", "SBIN", "CMCN", "SOAC", "DISP", "BOLD", "VCHR", "AROI", "TSUT", "PUOR"),
+	reflect.TypeOf(Frequency6Code("")):                oneOf("YEAR", "MNTH", "QURT", "MIAN", "WEEK", "DAIL", "ADHO", "INDA", "FRTN"),
+	reflect.TypeOf(Instruction3Code("")):              oneOf("CHQB", "HOLD", "PHOB", "TELB"),
+	reflect.TypeOf(Instruction4Code("")):              oneOf("PHOA", "TELA"),
+	reflect.TypeOf(MandateClassification1Code("")):    oneOf("FIXE", "USGB", "VARI"),
+	reflect.TypeOf(NamePrefix1Code("")):               oneOf("DOCT", "MIST", "MISS", "MADM"),
+	reflect.TypeOf(NamePrefix2Code("")):               oneOf("DOCT", "MADM", "MISS", "MIST", "MIKS"),
+	reflect.TypeOf(PaymentMethod4Code("")):            oneOf("CHK", "TRF", "DD", "TRA"),
+	reflect.TypeOf(PreferredContactMethod1Code("")):   oneOf("LETT", "MAIL", "PHON", "FAXX", "CELL"),
+	reflect.TypeOf(Priority2Code("")):                 oneOf("HIGH", "NORM"),
+	reflect.TypeOf(Priority3Code("")):                 oneOf("URGT", "HIGH", "NORM"),
+	reflect.TypeOf(RegulatoryReportingType1Code("")):  oneOf("CRED", "DEBT", "BOTH"),
+	reflect.TypeOf(RemittanceLocationMethod2Code("")): oneOf("FAXI", "EDIC", "URID", "EMAL", "POST", "SMSM"),
+	reflect.TypeOf(SequenceType3Code("")):             oneOf("FRST", "RCUR", "FNAL", "OOFF", "RPRE"),
+	reflect.TypeOf(SettlementMethod1Code("")):         oneOf("INDA", "INGA", "COVE", "CLRG"),
+	reflect.TypeOf(TaxRecordPeriod1Code("")):          oneOf("MM01", "MM02", "MM03", "MM04", "MM05", "MM06", "MM07", "MM08", "MM09", "MM10", "MM11", "MM12", "QTR1", "QTR2", "QTR3", "QTR4", "HLF1", "HLF2"),
+}
+
+// amountTypes have minInclusive 0 on their Value
+var amountTypes = map[reflect.Type]bool{
+	reflect.TypeOf(ActiveCurrencyAndAmount{}):           true,
+	reflect.TypeOf(ActiveOrHistoricCurrencyAndAmount{}): true,
+}
+
+// fieldViolation is a value breaking the rule of its simple type, Path names
+// the element from the root of the BusMsg
+type fieldViolation struct {
+	Path    string
+	Problem string
+}
+
+// validationErrors holds every violation found in a message
+type validationErrors []fieldViolation
+
+func (e validationErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, v := range e {
+		lines[i] = v.Path + ": " + v.Problem
+	}
+	return "invalid message: " + strings.Join(lines, "; ")
+}
+
+// Validate checks every simple type value in msg against the rules of
+// simpleTypeRules. A text element is mandatory when its xml tag has no
+// omitempty and reported missing when empty, unless an optional aggregate
+// around it is left out (nil). Empty optional elements are not checked.
+func (msg BusMsg) Validate() error {
+	var errs validationErrors
+	validateValue(reflect.ValueOf(msg.AppHdr), "AppHdr", &errs)
+	validateValue(reflect.ValueOf(msg.Document), "Document", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateValue(v reflect.Value, path string, errs *validationErrors) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			validateValue(v.Elem(), path, errs)
+		}
+
+	case reflect.Struct:
+		if amountTypes[v.Type()] {
+			if d := v.FieldByName("Value").Interface().(Decimal); d.unscaled < 0 {
+				*errs = append(*errs, fieldViolation{Path: path, Problem: fmt.Sprintf("%s is negative, minInclusive is 0", d)})
+			}
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" || f.Name == "XMLName" {
+				continue
+			}
+			fv := v.Field(i)
+			if fv.Kind() == reflect.String && fv.Len() == 0 && !strings.Contains(f.Tag.Get("xml"), ",omitempty") {
+				*errs = append(*errs, fieldViolation{Path: path + "." + f.Name, Problem: "missing, the element is mandatory"})
+				continue
+			}
+			validateValue(fv, path+"."+f.Name, errs)
+		}
+
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+
+	case reflect.String:
+		rule, ok := simpleTypeRules[v.Type()]
+		if !ok || v.Len() == 0 {
+			return
+		}
+		if problem := rule(v.String()); problem != "" {
+			*errs = append(*errs, fieldViolation{Path: path, Problem: problem})
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	const tx = "Document.FIToFICstmrCdtTrf.CdtTrfTxInf[0]"
+	tests := []struct {
+		name   string
+		change func(*CreditTransferTransaction39, *BusMsg)
+		want   []fieldViolation // Problem is a part of the reported one
+	}{
+		{"valid", func(*CreditTransferTransaction39, *BusMsg) {}, nil},
+		{"pattern", func(tx *CreditTransferTransaction39, _ *BusMsg) {
+			tx.CdtrAgt.FinInstnId.BICFI = "indoidja"
+		}, []fieldViolation{{tx + ".CdtrAgt.FinInstnId.BICFI", `"indoidja" does not match the pattern`}}},
+		{"pattern of an attribute", func(tx *CreditTransferTransaction39, _ *BusMsg) {
+			tx.IntrBkSttlmAmt.Ccy = "Rp"
+		}, []fieldViolation{{tx + ".IntrBkSttlmAmt.Ccy", `"Rp" does not match the pattern`}}},
+		{"length", func(tx *CreditTransferTransaction39, _ *BusMsg) {
+			tx.PmtId.EndToEndId = Max35Text(strings.Repeat("E", 36))
+		}, []fieldViolation{{tx + ".PmtId.EndToEndId", "36 characters, at most 35 allowed"}}},
+		{"length in characters", func(tx *CreditTransferTransaction39, _ *BusMsg) {
+			tx.Cdtr.Nm = Max140Text(strings.Repeat("é", 140))
+		}, nil},
+		{"code set", func(tx *CreditTransferTransaction39, _ *BusMsg) {
+			tx.ChrgBr = "FREE"
+		}, []fieldViolation{{tx + ".ChrgBr", `"FREE" is not one of`}}},
+		{"mandatory missing", func(tx *CreditTransferTransaction39, _ *BusMsg) {
+			tx.PmtId.EndToEndId = ""
+		}, []fieldViolation{{tx + ".PmtId.EndToEndId", "missing"}}},
+		{"mandatory missing in AppHdr", func(_ *CreditTransferTransaction39, msg *BusMsg) {
+			msg.AppHdr.BizMsgIdr = ""
+		}, []fieldViolation{{"AppHdr.BizMsgIdr", "missing"}}},
+		{"optional empty", func(tx *CreditTransferTransaction39, _ *BusMsg) {
+			tx.PmtId.InstrId = ""
+			tx.SttlmPrty = ""
+		}, nil},
+		{"optional aggregate left out", func(tx *CreditTransferTransaction39, _ *BusMsg) {
+			tx.CdtrAcct = nil
+		}, nil},
+		{"every violation", func(tx *CreditTransferTransaction39, msg *BusMsg) {
+			msg.AppHdr.BizMsgIdr = ""
+			tx.ChrgBr = "FREE"
+			tx.CdtrAgt.FinInstnId.BICFI = "indoidja"
+		}, []fieldViolation{
+			{"AppHdr.BizMsgIdr", "missing"},
+			{tx + ".ChrgBr", "is not one of"},
+			{tx + ".CdtrAgt.FinInstnId.BICFI", "does not match"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := goldenMessages(t)[kindPacs008]
+			tt.change(&msg.Document.FIToFICstmrCdtTrf.CdtTrfTxInf[0], &msg)
+
+			err := msg.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			errs, ok := err.(validationErrors)
+			if !ok || len(errs) != len(tt.want) {
+				t.Fatalf("Validate = %v, want %d violations", err, len(tt.want))
+			}
+			for i, want := range tt.want {
+				if errs[i].Path != want.Path || !strings.Contains(errs[i].Problem, want.Problem) {
+					t.Errorf("violation %d = %+v, want %+v", i, errs[i], want)
+				}
+			}
+		})
+	}
+}